@@ -0,0 +1,564 @@
+package arraylist
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/collections"
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// ArrayList is a resizable-array implementation of a list.
+// It mirrors java.util.ArrayList: elements are accessed by index in constant time,
+// and insertions and removals shift the elements that follow them.
+type ArrayList[E any] struct {
+	items  []E
+	equals util.Equals[E]
+
+	// hashable is set when the elements are compared with == by default, so they can be looked up in a hash set.
+	hashable bool
+
+	// modCount is the number of structural modifications, which change the size of this list.
+	// Iterators and sublists compare it with the value they expect, to fail fast instead of traversing a list modified under them.
+	modCount int
+}
+
+// splitMinChunk is the minimum number of elements handed to a worker at a time by SplitIterate.
+const splitMinChunk = 512
+
+// ArrayList implements the List interface.
+var _ collection.List[int] = (*ArrayList[int])(nil)
+
+// Option is a function type that sets the ArrayList.
+type Option[E any] func(*ArrayList[E])
+
+// WithCapacity is an option that sets the initial capacity.
+func WithCapacity[E any](initialCapacity int) Option[E] {
+	if initialCapacity < 0 {
+		panic("Illegal capacity")
+	}
+	return func(list *ArrayList[E]) {
+		list.items = make([]E, 0, initialCapacity)
+	}
+}
+
+// WithEquals is an option that sets the custom equality comparison function used by Contains, IndexOf and Remove.
+// Without it, elements are compared by util.DefaultEquals, which uses == when possible and reflect.DeepEqual otherwise.
+func WithEquals[E any](equals util.Equals[E]) Option[E] {
+	return func(list *ArrayList[E]) {
+		list.equals = equals
+	}
+}
+
+// New creates a new empty ArrayList with the given options.
+func New[E any](opts ...Option[E]) *ArrayList[E] {
+	list := &ArrayList[E]{}
+	for _, opt := range opts {
+		opt(list)
+	}
+	if list.equals == nil {
+		list.equals = util.DefaultEquals[E]()
+		list.hashable = listutil.Hashable[E]()
+	}
+	return list
+}
+
+// NewFromSlice creates a new ArrayList containing the elements in the given slice, in order.
+// The slice is copied; use FromSliceShared to avoid the copy.
+func NewFromSlice[E any](items []E, opts ...Option[E]) *ArrayList[E] {
+	list := New(opts...)
+	list.AddAll(items)
+	return list
+}
+
+// FromSliceShared creates a new ArrayList that uses the given slice as its backing array, without copying it.
+// The list aliases the slice: until the list grows beyond its capacity, Set, Sort and the other in-place operations
+// are visible through the slice, and writes to the slice are visible in the list. Appending may reallocate,
+// after which the two no longer share storage, so the caller should not use the slice once the list is modified.
+// The WithCapacity option is ignored.
+func FromSliceShared[E any](items []E, opts ...Option[E]) *ArrayList[E] {
+	list := New(opts...)
+	list.items = items
+	return list
+}
+
+// Appends the specified element to the end of this list.
+// boolean add(E e)
+func (list *ArrayList[E]) Add(item E) bool {
+	list.items = append(list.items, item)
+	list.modCount++
+	return true
+}
+
+// Inserts the specified element at the specified position in this list,
+// shifting the element currently at that position and any subsequent elements to the right.
+// void add(int index, E element)
+func (list *ArrayList[E]) AddAt(index int, item E) {
+	listutil.CheckIndex(index, len(list.items)+1)
+	var zero E
+	list.items = append(list.items, zero)
+	copy(list.items[index+1:], list.items[index:])
+	list.items[index] = item
+	list.modCount++
+}
+
+// Appends all of the elements in the specified slice to the end of this list, in order.
+// boolean addAll(Collection<? extends E> c)
+func (list *ArrayList[E]) AddAll(items []E) bool {
+	list.items = append(list.items, items...)
+	list.modCount++
+	return len(items) > 0
+}
+
+// Inserts all of the elements in the specified slice into this list at the specified position, in order.
+// The backing slice is grown at most once and the elements that follow are shifted a single time.
+// boolean addAll(int index, Collection<? extends E> c)
+func (list *ArrayList[E]) AddAllAt(index int, items []E) bool {
+	listutil.CheckIndex(index, len(list.items)+1)
+	list.insertAt(index, items)
+	return len(items) > 0
+}
+
+// Inserts the specified element at the beginning of this list, shifting the existing elements to the right.
+// void addFirst(E e)
+func (list *ArrayList[E]) AddFirst(item E) {
+	list.AddAt(0, item)
+}
+
+// Appends the specified element to the end of this list.
+// void addLast(E e)
+func (list *ArrayList[E]) AddLast(item E) {
+	list.items = append(list.items, item)
+	list.modCount++
+}
+
+// Backward returns an iterator over the elements in this list, in reverse order, for use with range-over-func.
+// It panics if the list is structurally modified during the iteration.
+func (list *ArrayList[E]) Backward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		expected := list.modCount
+		for i := len(list.items) - 1; i >= 0; i-- {
+			if !yield(list.items[i]) {
+				return
+			}
+			listutil.CheckModCount(list.modCount, expected)
+		}
+	}
+}
+
+// Searches this list, which must be sorted in ascending order according to the specified comparator,
+// or the natural ordering if it is nil, for the specified element in O(log n) time. It returns the index of the
+// first element equal to it if there is one, and otherwise (-(insertion point) - 1), where the insertion point
+// is the index at which the element would be inserted to keep the list sorted.
+// static <T> int binarySearch(List<? extends T> list, T key, Comparator<? super T> c)
+func (list *ArrayList[E]) BinarySearch(item E, comparator util.Comparator[E]) int {
+	if comparator == nil {
+		comparator = util.DefaultComparator[E]()
+	}
+	i, found := slices.BinarySearchFunc(list.items, item, comparator)
+	if !found {
+		return -(i + 1)
+	}
+	return i
+}
+
+// Removes all of the elements from this list.
+// void clear()
+func (list *ArrayList[E]) Clear() {
+	clear(list.items)
+	list.items = list.items[:0]
+	list.modCount++
+}
+
+// Returns the capacity of the backing slice, that is, the number of elements this list can hold without reallocating.
+func (list *ArrayList[E]) Capacity() int {
+	return cap(list.items)
+}
+
+// Returns true if this list contains the specified element.
+// boolean contains(Object o)
+func (list *ArrayList[E]) Contains(item E) bool {
+	return list.IndexOf(item) >= 0
+}
+
+// Returns true if this list contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (list *ArrayList[E]) ContainsAll(items []E) bool {
+	for _, item := range items {
+		if !list.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Increases the capacity of the backing slice, if necessary, so that it can hold at least minCapacity elements without reallocating.
+// void ensureCapacity(int minCapacity)
+func (list *ArrayList[E]) EnsureCapacity(minCapacity int) {
+	if minCapacity > cap(list.items) {
+		list.items = slices.Grow(list.items, minCapacity-len(list.items))
+	}
+}
+
+// Returns true if the other list contains equal elements in the same order, regardless of its implementation.
+// The elements are compared with the equality function of this list.
+// boolean equals(Object o)
+func (list *ArrayList[E]) Equals(other collection.List[E]) bool {
+	return listutil.EqualElements(list.All(), list.Size(), other, list.equals)
+}
+
+// Performs the given action for each element of this list, in order.
+// void forEach(Consumer<? super E> action)
+func (list *ArrayList[E]) ForEach(action util.Consumer[E]) {
+	expected := list.modCount
+	for _, v := range list.items {
+		action(v)
+		listutil.CheckModCount(list.modCount, expected)
+	}
+}
+
+// Returns the element at the specified position in this list.
+// E get(int index)
+func (list *ArrayList[E]) Get(index int) E {
+	listutil.CheckIndex(index, len(list.items))
+	return list.items[index]
+}
+
+// Returns the first element in this list, and panics if this list is empty.
+// E getFirst()
+func (list *ArrayList[E]) GetFirst() E {
+	if len(list.items) == 0 {
+		panic("No such element")
+	}
+	return list.items[0]
+}
+
+// Returns the last element in this list, and panics if this list is empty.
+// E getLast()
+func (list *ArrayList[E]) GetLast() E {
+	if len(list.items) == 0 {
+		panic("No such element")
+	}
+	return list.items[len(list.items)-1]
+}
+
+// Returns the hash code of this list, computed from the hash codes of its elements in order as java.util.List specifies.
+// Equal lists have equal hash codes when their elements are compared with the default equality.
+// int hashCode()
+func (list *ArrayList[E]) HashCode() uint64 {
+	return listutil.HashElements(list.All())
+}
+
+// Returns the index of the first occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int indexOf(Object o)
+func (list *ArrayList[E]) IndexOf(item E) int {
+	for i, v := range list.items {
+		if list.equals(v, item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// InsertSorted inserts the specified element into this list, which must be sorted in ascending order according to
+// the specified comparator, or the natural ordering if it is nil, at the position that keeps it sorted, and returns that
+// position. The element is inserted after any equal elements, so inserting elements one by one sorts them stably.
+// The position is found in O(log n) time, and the insertion shifts the elements that follow it in O(n) time.
+func (list *ArrayList[E]) InsertSorted(item E, comparator util.Comparator[E]) int {
+	if comparator == nil {
+		comparator = util.DefaultComparator[E]()
+	}
+	low, high := 0, len(list.items)
+	for low < high {
+		mid := int(uint(low+high) >> 1)
+		if comparator(list.items[mid], item) <= 0 {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	list.insertAt(low, []E{item})
+	return low
+}
+
+// Returns the index of the last occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int lastIndexOf(Object o)
+func (list *ArrayList[E]) LastIndexOf(item E) int {
+	for i := len(list.items) - 1; i >= 0; i-- {
+		if list.equals(list.items[i], item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns true if this list contains no elements.
+// boolean isEmpty()
+func (list *ArrayList[E]) IsEmpty() bool {
+	return len(list.items) == 0
+}
+
+// All returns an iterator over the elements in this list, in order, for use with range-over-func.
+// It panics if the list is structurally modified during the iteration.
+func (list *ArrayList[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		expected := list.modCount
+		for _, v := range list.items {
+			if !yield(v) {
+				return
+			}
+			listutil.CheckModCount(list.modCount, expected)
+		}
+	}
+}
+
+// Removes the first occurrence of the specified element from this list, if it is present.
+// boolean remove(Object o)
+func (list *ArrayList[E]) Remove(item E) bool {
+	i := list.IndexOf(item)
+	if i < 0 {
+		return false
+	}
+	list.RemoveAt(i)
+	return true
+}
+
+// Removes all of the elements of this list that satisfy the given predicate.
+// The remaining elements are compacted in a single pass, in O(n) time.
+// boolean removeIf(Predicate<? super E> filter)
+func (list *ArrayList[E]) RemoveIf(filter util.Predicate[E]) bool {
+	n := len(list.items)
+	list.items = slices.DeleteFunc(list.items, filter)
+	if len(list.items) == n {
+		return false
+	}
+	list.modCount++
+	return true
+}
+
+// Removes all of this list's elements that are also contained in the specified slice, in a single pass.
+// boolean removeAll(Collection<?> c)
+func (list *ArrayList[E]) RemoveAll(items []E) bool {
+	return list.RemoveIf(listutil.Membership(items, list.equals, list.hashable))
+}
+
+// Removes the element at the specified position in this list, shifting any subsequent elements to the left.
+// Returns the element that was removed.
+// E remove(int index)
+func (list *ArrayList[E]) RemoveAt(index int) E {
+	listutil.CheckIndex(index, len(list.items))
+	item := list.items[index]
+	copy(list.items[index:], list.items[index+1:])
+	var zero E
+	list.items[len(list.items)-1] = zero
+	list.items = list.items[:len(list.items)-1]
+	list.modCount++
+	return item
+}
+
+// Removes and returns the first element of this list, and panics if this list is empty.
+// E removeFirst()
+func (list *ArrayList[E]) RemoveFirst() E {
+	if len(list.items) == 0 {
+		panic("No such element")
+	}
+	return list.RemoveAt(0)
+}
+
+// Removes and returns the last element of this list, and panics if this list is empty.
+// E removeLast()
+func (list *ArrayList[E]) RemoveLast() E {
+	if len(list.items) == 0 {
+		panic("No such element")
+	}
+	return list.RemoveAt(len(list.items) - 1)
+}
+
+// Retains only the elements in this list that are contained in the specified slice, in a single pass.
+// boolean retainAll(Collection<?> c)
+func (list *ArrayList[E]) RetainAll(items []E) bool {
+	contains := listutil.Membership(items, list.equals, list.hashable)
+	return list.RemoveIf(func(v E) bool {
+		return !contains(v)
+	})
+}
+
+// Replaces each element of this list with the result of applying the operator to that element.
+// void replaceAll(UnaryOperator<E> operator)
+func (list *ArrayList[E]) ReplaceAll(operator util.UnaryOperator[E]) {
+	for i, v := range list.items {
+		list.items[i] = operator(v)
+	}
+}
+
+// RandomAccess marks this list as supporting constant-time access by index.
+func (list *ArrayList[E]) RandomAccess() {}
+
+// Returns a reverse-ordered view of this list, as collections.Reversed does.
+// Changes made through the view are written through to this list, and changes to this list are visible in the view.
+// List<E> reversed()
+func (list *ArrayList[E]) Reversed() collection.List[E] {
+	return collections.Reversed[E](list)
+}
+
+// Replaces the element at the specified position in this list with the specified element.
+// Returns the element previously at the specified position.
+// E set(int index, E element)
+func (list *ArrayList[E]) Set(index int, item E) E {
+	listutil.CheckIndex(index, len(list.items))
+	old := list.items[index]
+	list.items[index] = item
+	return old
+}
+
+// Returns the number of elements in this list.
+// int size()
+func (list *ArrayList[E]) Size() int {
+	return len(list.items)
+}
+
+// SplitIterate performs the given action for each element of this list in parallel, for CPU-bound per-element work on
+// large lists. The backing slice is split into chunks that are processed by a pool of parallelism goroutines, or
+// runtime.GOMAXPROCS(0) goroutines if parallelism is not positive, and it returns once every element has been visited.
+// The action is called concurrently and in no particular order, so it must be safe for concurrent use, and the list
+// must not be structurally modified until SplitIterate returns; such a modification is reported with a panic at the end.
+// If the action panics, the first panic is re-raised in the calling goroutine after the workers have stopped.
+func (list *ArrayList[E]) SplitIterate(parallelism int, action util.Consumer[E]) {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	items, expected := list.items, list.modCount
+	// A few chunks per worker balance uneven work without contending on the counter for every element.
+	chunkSize := max((len(items)+4*parallelism-1)/(4*parallelism), splitMinChunk)
+	chunks := (len(items) + chunkSize - 1) / chunkSize
+	if chunks <= 1 {
+		for _, v := range items {
+			action(v)
+		}
+		listutil.CheckModCount(list.modCount, expected)
+		return
+	}
+
+	var (
+		next      atomic.Int64
+		wg        sync.WaitGroup
+		panicOnce sync.Once
+		panicked  bool
+		recovered any
+	)
+	for range min(parallelism, chunks) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() {
+						panicked, recovered = true, r
+					})
+					next.Store(int64(chunks))
+				}
+			}()
+			for {
+				c := int(next.Add(1) - 1)
+				if c >= chunks {
+					return
+				}
+				for _, v := range items[c*chunkSize : min((c+1)*chunkSize, len(items))] {
+					action(v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if panicked {
+		panic(recovered)
+	}
+	listutil.CheckModCount(list.modCount, expected)
+}
+
+// Sorts this list according to the order induced by the specified comparator.
+// The sort is stable, so equal elements keep their relative order. If the comparator is nil,
+// the elements are sorted by util.DefaultComparator, which uses their natural ordering.
+// void sort(Comparator<? super E> c)
+func (list *ArrayList[E]) Sort(comparator util.Comparator[E]) {
+	sortStable(list.items, comparator)
+}
+
+// SortOrdered sorts the list of an ordered type, such as int64, uint or float32, in its natural ordering.
+func SortOrdered[E cmp.Ordered](list *ArrayList[E]) {
+	list.Sort(util.NaturalOrder[E]())
+}
+
+// Trims the capacity of the backing slice to the number of elements in this list, releasing unused memory.
+// void trimToSize()
+func (list *ArrayList[E]) TrimToSize() {
+	if cap(list.items) > len(list.items) {
+		list.items = slices.Clone(list.items)
+	}
+}
+
+// Returns an array containing all of the elements in this list, in order.
+// The elements are copied; use AsSlice to avoid the copy.
+// Object[] toArray()
+func (list *ArrayList[E]) ToArray() []E {
+	return append([]E(nil), list.items...)
+}
+
+// AsSlice returns the backing slice of this list, without copying it.
+// The slice aliases the list: writes to its elements are visible in the list, and in-place operations on the list
+// are visible through it, but any structural modification of the list may reallocate or shift the elements,
+// so the slice should be treated as valid only until the list is next modified.
+// Its capacity is limited to its length, so appending to it never overwrites the spare capacity of the list.
+func (list *ArrayList[E]) AsSlice() []E {
+	return list.items[:len(list.items):len(list.items)]
+}
+
+// AppendTo appends the elements of this list, in order, to dst and returns the extended slice, as append does.
+// It copies the elements without allocating when dst has enough spare capacity, so a buffer can be reused across calls.
+func (list *ArrayList[E]) AppendTo(dst []E) []E {
+	return append(dst, list.items...)
+}
+
+// Returns a string representation of this list, in the form "[e1, e2, e3]".
+// String toString()
+func (list *ArrayList[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, v := range list.items {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, v)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// insertAt inserts the elements at the index, growing the backing slice at most once and shifting the tail a single time.
+func (list *ArrayList[E]) insertAt(index int, items []E) {
+	list.items = slices.Insert(list.items, index, items...)
+	list.modCount++
+}
+
+// removeRange removes the elements between fromIndex, inclusive, and toIndex, exclusive.
+// void removeRange(int fromIndex, int toIndex)
+func (list *ArrayList[E]) removeRange(fromIndex, toIndex int) {
+	list.items = slices.Delete(list.items, fromIndex, toIndex)
+	list.modCount++
+}
+
+// sortStable sorts the items stably, by util.DefaultComparator if the comparator is nil.
+func sortStable[E any](items []E, comparator util.Comparator[E]) {
+	if comparator == nil {
+		comparator = util.DefaultComparator[E]()
+	}
+	slices.SortStableFunc(items, comparator)
+}
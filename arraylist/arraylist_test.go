@@ -0,0 +1,98 @@
+package arraylist
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nsce9806q/javastyle-collection/linkedlist"
+)
+
+func TestHashCodeFollowsPointers(t *testing.T) {
+	a, b := 1, 1
+	list := NewFromSlice([][]*int{{&a}})
+	other := linkedlist.NewFromSlice([][]*int{{&b}})
+	if !list.Equals(other) {
+		t.Fatal("Equals() = false, want true for slices of pointers to equal values")
+	}
+	if list.HashCode() != other.HashCode() {
+		t.Error("HashCode() differs for equal lists")
+	}
+	if list.SubList(0, 1).HashCode() != list.HashCode() {
+		t.Error("HashCode() of the full sublist differs from the list")
+	}
+}
+
+func TestSplitIterateVisitsEveryElementOnce(t *testing.T) {
+	for _, n := range []int{0, 1, splitMinChunk, 10*splitMinChunk + 7} {
+		for _, parallelism := range []int{0, 1, 3, 16} {
+			items := make([]int, n)
+			for i := range items {
+				items[i] = i
+			}
+			list := NewFromSlice(items)
+
+			visits := make([]atomic.Int32, n)
+			list.SplitIterate(parallelism, func(v int) {
+				visits[v].Add(1)
+			})
+			for i := range visits {
+				if got := visits[i].Load(); got != 1 {
+					t.Fatalf("n=%d parallelism=%d: element %d visited %d times", n, parallelism, i, got)
+				}
+			}
+		}
+	}
+}
+
+func TestSplitIterateConcurrentAction(t *testing.T) {
+	items := make([]int, 20*splitMinChunk)
+	for i := range items {
+		items[i] = i
+	}
+	list := NewFromSlice(items)
+
+	// the action updates shared state under a lock, which the race detector checks
+	var mu sync.Mutex
+	sum := 0
+	list.SplitIterate(8, func(v int) {
+		mu.Lock()
+		sum += v
+		mu.Unlock()
+	})
+	if want := len(items) * (len(items) - 1) / 2; sum != want {
+		t.Errorf("sum = %d, want %d", sum, want)
+	}
+}
+
+func TestSplitIteratePanic(t *testing.T) {
+	list := NewFromSlice(make([]int, 20*splitMinChunk))
+	var calls atomic.Int32
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("recovered %v, want boom", r)
+		}
+	}()
+	list.SplitIterate(4, func(int) {
+		if calls.Add(1) == 100 {
+			panic("boom")
+		}
+	})
+	t.Error("SplitIterate did not panic")
+}
+
+func TestSplitIterateModification(t *testing.T) {
+	list := NewFromSlice(make([]int, 4*splitMinChunk))
+	var once sync.Once
+	defer func() {
+		if r := recover(); r != "Concurrent modification" {
+			t.Errorf("recovered %v, want Concurrent modification", r)
+		}
+	}()
+	list.SplitIterate(1, func(int) {
+		once.Do(func() {
+			list.Add(1)
+		})
+	})
+	t.Error("SplitIterate did not panic")
+}
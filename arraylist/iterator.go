@@ -0,0 +1,167 @@
+package arraylist
+
+import (
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+)
+
+// ListIterator is an iterator over the elements of an ArrayList or a SubList that allows traversal in either direction
+// and modification of the list during iteration. Its cursor always lies between two elements.
+// If the list is structurally modified other than through the iterator, the iterator fails fast:
+// its methods panic with "Concurrent modification".
+type ListIterator[E any] struct {
+	list positional[E]
+
+	// cursor is the index of the element to be returned by the next call to Next.
+	cursor int
+
+	// lastRet is the index of the element returned by the most recent call to Next or Previous,
+	// or -1 if there is no such element or it has been removed or followed by a call to Add.
+	lastRet int
+
+	// expectedModCount is the modCount of the backing ArrayList that this iterator expects.
+	expectedModCount int
+}
+
+// positional is implemented by the lists a ListIterator can traverse.
+type positional[E any] interface {
+	Get(index int) E
+	Set(index int, item E) E
+	AddAt(index int, item E)
+	RemoveAt(index int) E
+	Size() int
+
+	// backing returns the ArrayList whose modCount the iterator checks.
+	backing() *ArrayList[E]
+}
+
+// Returns a list iterator over the elements in this list, starting at the beginning of the list.
+// ListIterator<E> listIterator()
+func (list *ArrayList[E]) ListIterator() *ListIterator[E] {
+	return list.ListIteratorAt(0)
+}
+
+// Returns a list iterator over the elements in this list, starting at the specified position in the list.
+// ListIterator<E> listIterator(int index)
+func (list *ArrayList[E]) ListIteratorAt(index int) *ListIterator[E] {
+	return newListIterator[E](list, index)
+}
+
+// Returns a list iterator over the elements in this sublist, starting at the beginning of the sublist.
+// ListIterator<E> listIterator()
+func (sub *SubList[E]) ListIterator() *ListIterator[E] {
+	return sub.ListIteratorAt(0)
+}
+
+// Returns a list iterator over the elements in this sublist, starting at the specified position in the sublist.
+// ListIterator<E> listIterator(int index)
+func (sub *SubList[E]) ListIteratorAt(index int) *ListIterator[E] {
+	return newListIterator[E](sub, index)
+}
+
+// newListIterator returns a list iterator over the list whose cursor is before the element at index.
+func newListIterator[E any](list positional[E], index int) *ListIterator[E] {
+	listutil.CheckIndex(index, list.Size()+1)
+	return &ListIterator[E]{
+		list:             list,
+		cursor:           index,
+		lastRet:          -1,
+		expectedModCount: list.backing().modCount,
+	}
+}
+
+// backing returns this list, which an iterator over it checks for modifications.
+func (list *ArrayList[E]) backing() *ArrayList[E] {
+	return list
+}
+
+// backing returns the backing list of this sublist, which an iterator over it checks for modifications.
+func (sub *SubList[E]) backing() *ArrayList[E] {
+	return sub.root
+}
+
+// Returns true if this list iterator has more elements when traversing the list in the forward direction.
+// boolean hasNext()
+func (it *ListIterator[E]) HasNext() bool {
+	return it.cursor < it.list.Size()
+}
+
+// Returns the next element in the list and advances the cursor position.
+// E next()
+func (it *ListIterator[E]) Next() E {
+	it.checkForComodification()
+	if !it.HasNext() {
+		panic("No such element")
+	}
+	it.lastRet = it.cursor
+	it.cursor++
+	return it.list.Get(it.lastRet)
+}
+
+// Returns true if this list iterator has more elements when traversing the list in the reverse direction.
+// boolean hasPrevious()
+func (it *ListIterator[E]) HasPrevious() bool {
+	return it.cursor > 0
+}
+
+// Returns the previous element in the list and moves the cursor position backwards.
+// E previous()
+func (it *ListIterator[E]) Previous() E {
+	it.checkForComodification()
+	if !it.HasPrevious() {
+		panic("No such element")
+	}
+	it.cursor--
+	it.lastRet = it.cursor
+	return it.list.Get(it.lastRet)
+}
+
+// Returns the index of the element that would be returned by a subsequent call to Next, or the list size if at the end of the list.
+// int nextIndex()
+func (it *ListIterator[E]) NextIndex() int {
+	return it.cursor
+}
+
+// Returns the index of the element that would be returned by a subsequent call to Previous, or -1 if at the beginning of the list.
+// int previousIndex()
+func (it *ListIterator[E]) PreviousIndex() int {
+	return it.cursor - 1
+}
+
+// Removes from the list the last element that was returned by Next or Previous.
+// void remove()
+func (it *ListIterator[E]) Remove() {
+	if it.lastRet < 0 {
+		panic("Illegal state")
+	}
+	it.checkForComodification()
+	it.list.RemoveAt(it.lastRet)
+	it.cursor = it.lastRet
+	it.lastRet = -1
+	it.expectedModCount = it.list.backing().modCount
+}
+
+// Replaces the last element returned by Next or Previous with the specified element.
+// void set(E e)
+func (it *ListIterator[E]) Set(item E) {
+	if it.lastRet < 0 {
+		panic("Illegal state")
+	}
+	it.checkForComodification()
+	it.list.Set(it.lastRet, item)
+}
+
+// Inserts the specified element into the list immediately before the element that would be returned by Next.
+// A subsequent call to Previous returns the new element.
+// void add(E e)
+func (it *ListIterator[E]) Add(item E) {
+	it.checkForComodification()
+	it.list.AddAt(it.cursor, item)
+	it.cursor++
+	it.lastRet = -1
+	it.expectedModCount = it.list.backing().modCount
+}
+
+// checkForComodification panics if the list has been structurally modified other than through this iterator.
+func (it *ListIterator[E]) checkForComodification() {
+	listutil.CheckModCount(it.list.backing().modCount, it.expectedModCount)
+}
@@ -0,0 +1,219 @@
+package collection
+
+import (
+	"iter"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// Collection is the root interface of the collection hierarchy.
+// It mirrors java.util.Collection.
+type Collection[E any] interface {
+	// Ensures that this collection contains the specified element.
+	// boolean add(E e)
+	Add(e E) bool
+
+	// Adds all of the elements in the specified slice to this collection.
+	// boolean addAll(Collection<? extends E> c)
+	AddAll(items []E) bool
+
+	// Removes all of the elements from this collection.
+	// void clear()
+	Clear()
+
+	// Returns true if this collection contains the specified element.
+	// boolean contains(Object o)
+	Contains(e E) bool
+
+	// Returns true if this collection contains all of the elements in the specified slice.
+	// boolean containsAll(Collection<?> c)
+	ContainsAll(items []E) bool
+
+	// Performs the given action for each element of this collection.
+	// void forEach(Consumer<? super T> action)
+	ForEach(action util.Consumer[E])
+
+	// Returns true if this collection contains no elements.
+	// boolean isEmpty()
+	IsEmpty() bool
+
+	// Returns an iterator over the elements in this collection, for use with range-over-func.
+	All() iter.Seq[E]
+
+	// Removes a single instance of the specified element from this collection, if it is present.
+	// boolean remove(Object o)
+	Remove(e E) bool
+
+	// Removes all of this collection's elements that are also contained in the specified slice.
+	// boolean removeAll(Collection<?> c)
+	RemoveAll(items []E) bool
+
+	// Removes all of the elements of this collection that satisfy the given predicate.
+	// boolean removeIf(Predicate<? super E> filter)
+	RemoveIf(filter util.Predicate[E]) bool
+
+	// Retains only the elements in this collection that are contained in the specified slice.
+	// boolean retainAll(Collection<?> c)
+	RetainAll(items []E) bool
+
+	// Returns the number of elements in this collection.
+	// int size()
+	Size() int
+
+	// Returns an array containing all of the elements in this collection.
+	// Object[] toArray()
+	ToArray() []E
+}
+
+// Queue is a collection designed for holding elements prior to processing.
+// It mirrors java.util.Queue.
+type Queue[E any] interface {
+	Collection[E]
+
+	// Inserts the specified element into this queue if it is possible to do so without violating capacity restrictions.
+	// boolean offer(E e)
+	Offer(e E) bool
+
+	// Retrieves and removes the head of this queue, or returns zero value if this queue is empty.
+	// E poll()
+	Poll() E
+
+	// Retrieves, but does not remove, the head of this queue, or returns zero value if this queue is empty.
+	// E peek()
+	Peek() E
+
+	// Retrieves and removes the head of this queue, and panics if this queue is empty.
+	// E remove()
+	RemoveHead() E
+
+	// Retrieves, but does not remove, the head of this queue, and panics if this queue is empty.
+	// E element()
+	Element() E
+}
+
+// List is an ordered collection whose elements can be accessed by their integer index.
+// It mirrors java.util.List, and is implemented by arraylist.ArrayList, linkedlist.LinkedList, their sublist views, gaplist.GapList and unrolledlist.UnrolledList.
+// The methods inherited from Collection visit the elements in order.
+type List[E any] interface {
+	Collection[E]
+
+	// Inserts the specified element at the specified position in this list.
+	// void add(int index, E element)
+	AddAt(index int, e E)
+
+	// Inserts all of the elements in the specified slice into this list at the specified position, in order.
+	// boolean addAll(int index, Collection<? extends E> c)
+	AddAllAt(index int, items []E) bool
+
+	// Returns true if the other list contains equal elements in the same order, regardless of its implementation.
+	// boolean equals(Object o)
+	Equals(other List[E]) bool
+
+	// Returns the element at the specified position in this list.
+	// E get(int index)
+	Get(index int) E
+
+	// Returns the hash code of this list, combining the hash codes of its elements in order.
+	// Lists with equal elements in the same order have equal hash codes.
+	// int hashCode()
+	HashCode() uint64
+
+	// Returns the index of the first occurrence of the specified element in this list, or -1 if this list does not contain the element.
+	// int indexOf(Object o)
+	IndexOf(e E) int
+
+	// Returns the index of the last occurrence of the specified element in this list, or -1 if this list does not contain the element.
+	// int lastIndexOf(Object o)
+	LastIndexOf(e E) int
+
+	// Removes the element at the specified position in this list.
+	// E remove(int index)
+	RemoveAt(index int) E
+
+	// Replaces each element of this list with the result of applying the operator to that element.
+	// void replaceAll(UnaryOperator<E> operator)
+	ReplaceAll(operator util.UnaryOperator[E])
+
+	// Replaces the element at the specified position in this list with the specified element.
+	// E set(int index, E element)
+	Set(index int, e E) E
+
+	// Sorts this list stably according to the order induced by the specified comparator, or the natural ordering if it is nil.
+	// void sort(Comparator<? super E> c)
+	Sort(comparator util.Comparator[E])
+}
+
+// RandomAccess is a marker interface implemented by the lists that access their elements by index in constant time,
+// such as arraylist.ArrayList and gaplist.GapList. Generic list algorithms check for it to choose between index-based
+// loops and a single sequential pass, so that lists such as linkedlist.LinkedList do not get quadratic behavior.
+// It mirrors java.util.RandomAccess.
+type RandomAccess interface {
+	// RandomAccess marks the list, and does nothing.
+	RandomAccess()
+}
+
+// Map is an object that maps keys to values, where each key maps to at most one value.
+// It mirrors java.util.Map, and is implemented by hashmap.HashMap and linkedhashmap.LinkedHashMap.
+// Methods that return a value return the zero value when the key is not mapped, as Go maps do.
+type Map[K, V any] interface {
+	// Removes all of the mappings from this map.
+	// void clear()
+	Clear()
+
+	// Returns true if this map contains a mapping for the specified key.
+	// boolean containsKey(Object key)
+	ContainsKey(key K) bool
+
+	// Returns true if this map maps one or more keys to the specified value.
+	// boolean containsValue(Object value)
+	ContainsValue(value V) bool
+
+	// Performs the given action for each mapping in this map.
+	// void forEach(BiConsumer<? super K,? super V> action)
+	ForEach(action util.BiConsumer[K, V])
+
+	// Returns the value to which the specified key is mapped, or the zero value if this map contains no mapping for the key.
+	// V get(Object key)
+	Get(key K) V
+
+	// Returns the value to which the specified key is mapped, and whether this map contains a mapping for the key.
+	GetOK(key K) (V, bool)
+
+	// Returns the value to which the specified key is mapped, or defaultValue if this map contains no mapping for the key.
+	// V getOrDefault(Object key, V defaultValue)
+	GetOrDefault(key K, defaultValue V) V
+
+	// Returns true if this map contains no mappings.
+	// boolean isEmpty()
+	IsEmpty() bool
+
+	// Returns an iterator over the mappings in this map, for use with range-over-func.
+	All() iter.Seq2[K, V]
+
+	// Returns an iterator over the keys in this map, for use with range-over-func.
+	// Set<K> keySet()
+	Keys() iter.Seq[K]
+
+	// Returns an iterator over the values in this map, for use with range-over-func.
+	// Collection<V> values()
+	Values() iter.Seq[V]
+
+	// Associates the specified value with the specified key in this map.
+	// Returns the previous value associated with the key, or the zero value if there was no mapping for the key.
+	// V put(K key, V value)
+	Put(key K, value V) V
+
+	// Associates the specified value with the specified key if the key is not already mapped.
+	// Returns the current value associated with the key, or the zero value if there was no mapping for the key.
+	// V putIfAbsent(K key, V value)
+	PutIfAbsent(key K, value V) V
+
+	// Removes the mapping for a key from this map if it is present.
+	// Returns the value previously associated with the key, or the zero value if there was no mapping for the key.
+	// V remove(Object key)
+	Remove(key K) V
+
+	// Returns the number of mappings in this map.
+	// int size()
+	Size() int
+}
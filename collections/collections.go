@@ -0,0 +1,140 @@
+package collections
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// The algorithms below work in place with Get and Set on lists that implement collection.RandomAccess.
+// Other lists, such as linkedlist.LinkedList, are copied out with ToArray and written back with a single
+// ReplaceAll pass, so they run in linear time instead of paying a linear-time Get or Set for every index.
+
+// Sort sorts the specified list stably according to the order induced by the specified comparator,
+// or the natural ordering if it is nil. Every list sorts itself in the way that suits its structure,
+// so it is equivalent to list.Sort(comparator).
+// static <T> void sort(List<T> list, Comparator<? super T> c)
+func Sort[E any](list collection.List[E], comparator util.Comparator[E]) {
+	list.Sort(comparator)
+}
+
+// BinarySearch searches the specified list, which must be sorted in ascending order according to the specified
+// comparator, or the natural ordering if it is nil, for the specified key. It returns the index of the key if it is
+// found, and otherwise (-(insertion point) - 1), where the insertion point is the index at which the key would be inserted.
+// If the list contains several elements equal to the key, there is no guarantee which one is found.
+// A random-access list is searched in O(log n) time, and any other list in O(n) time with O(log n) comparisons.
+// static <T> int binarySearch(List<? extends T> list, T key, Comparator<? super T> c)
+func BinarySearch[E any](list collection.List[E], key E, comparator util.Comparator[E]) int {
+	if comparator == nil {
+		comparator = util.DefaultComparator[E]()
+	}
+	if _, ok := list.(collection.RandomAccess); ok {
+		low, high := 0, list.Size()-1
+		for low <= high {
+			mid := int(uint(low+high) >> 1)
+			switch c := comparator(list.Get(mid), key); {
+			case c < 0:
+				low = mid + 1
+			case c > 0:
+				high = mid - 1
+			default:
+				return mid
+			}
+		}
+		return -(low + 1)
+	}
+	i, found := slices.BinarySearchFunc(list.ToArray(), key, comparator)
+	if !found {
+		return -(i + 1)
+	}
+	return i
+}
+
+// Shuffle randomly permutes the elements of the specified list using the specified source of randomness,
+// or the global source of math/rand/v2 if it is nil. All permutations occur with approximately equal likelihood.
+// static void shuffle(List<?> list, Random rnd)
+func Shuffle[E any](list collection.List[E], rnd *rand.Rand) {
+	intN := rand.IntN
+	if rnd != nil {
+		intN = rnd.IntN
+	}
+	if _, ok := list.(collection.RandomAccess); ok {
+		for i := list.Size() - 1; i > 0; i-- {
+			swap(list, i, intN(i+1))
+		}
+		return
+	}
+	items := list.ToArray()
+	for i := len(items) - 1; i > 0; i-- {
+		j := intN(i + 1)
+		items[i], items[j] = items[j], items[i]
+	}
+	writeBack(list, items)
+}
+
+// Reverse reverses the order of the elements in the specified list, in linear time.
+// static void reverse(List<?> list)
+func Reverse[E any](list collection.List[E]) {
+	if _, ok := list.(collection.RandomAccess); ok {
+		reverseRange(list, 0, list.Size())
+		return
+	}
+	items := list.ToArray()
+	slices.Reverse(items)
+	writeBack(list, items)
+}
+
+// Rotate rotates the elements in the specified list by the specified distance, in linear time.
+// After the call, the element at index i is the one that was previously at index (i - distance) mod list.Size().
+// The distance may be zero, negative, or greater than the size of the list.
+// static void rotate(List<?> list, int distance)
+func Rotate[E any](list collection.List[E], distance int) {
+	n := list.Size()
+	if n == 0 {
+		return
+	}
+	distance %= n
+	if distance < 0 {
+		distance += n
+	}
+	if distance == 0 {
+		return
+	}
+	if _, ok := list.(collection.RandomAccess); ok {
+		reverseRange(list, 0, n)
+		reverseRange(list, 0, distance)
+		reverseRange(list, distance, n)
+		return
+	}
+	items := list.ToArray()
+	slices.Reverse(items)
+	slices.Reverse(items[:distance])
+	slices.Reverse(items[distance:])
+	writeBack(list, items)
+}
+
+// swap swaps the elements at the indexes i and j of a random-access list.
+// static void swap(List<?> list, int i, int j)
+func swap[E any](list collection.List[E], i, j int) {
+	list.Set(i, list.Set(j, list.Get(i)))
+}
+
+// reverseRange reverses the elements between fromIndex, inclusive, and toIndex, exclusive, of a random-access list.
+func reverseRange[E any](list collection.List[E], fromIndex, toIndex int) {
+	for i, j := fromIndex, toIndex-1; i < j; i, j = i+1, j-1 {
+		swap(list, i, j)
+	}
+}
+
+// writeBack replaces the elements of the list, in order, with the elements of items, which has the same length.
+// It uses ReplaceAll, which visits the elements in a single pass for every list implementation.
+func writeBack[E any](list collection.List[E], items []E) {
+	i := 0
+	list.ReplaceAll(func(E) E {
+		v := items[i]
+		i++
+		return v
+	})
+}
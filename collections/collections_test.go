@@ -0,0 +1,179 @@
+package collections_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nsce9806q/javastyle-collection/arraylist"
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/collections"
+	"github.com/nsce9806q/javastyle-collection/linkedlist"
+)
+
+// newLists returns a random-access list and a sequential list holding the same elements,
+// to exercise both code paths of the algorithms.
+func newLists(items ...int) map[string]collection.List[int] {
+	return map[string]collection.List[int]{
+		"ArrayList":  arraylist.NewFromSlice(slices.Clone(items)),
+		"LinkedList": linkedlist.NewFromSlice(slices.Clone(items)),
+	}
+}
+
+func TestReverseAndRotate(t *testing.T) {
+	for name, list := range newLists(1, 2, 3, 4, 5) {
+		collections.Reverse(list)
+		if got := list.ToArray(); !slices.Equal(got, []int{5, 4, 3, 2, 1}) {
+			t.Errorf("%s: Reverse = %v, want [5 4 3 2 1]", name, got)
+		}
+		collections.Rotate(list, 2)
+		if got := list.ToArray(); !slices.Equal(got, []int{2, 1, 5, 4, 3}) {
+			t.Errorf("%s: Rotate(2) = %v, want [2 1 5 4 3]", name, got)
+		}
+		collections.Rotate(list, -7)
+		if got := list.ToArray(); !slices.Equal(got, []int{5, 4, 3, 2, 1}) {
+			t.Errorf("%s: Rotate(-7) = %v, want [5 4 3 2 1]", name, got)
+		}
+	}
+	collections.Rotate(arraylist.New[int](), 3)
+}
+
+func TestShuffleIsPermutation(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	for name, list := range newLists(items...) {
+		collections.Shuffle(list, rand.New(rand.NewPCG(1, 2)))
+		got := list.ToArray()
+		if slices.Equal(got, items) {
+			t.Errorf("%s: Shuffle left the list in order", name)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, items) {
+			t.Errorf("%s: Shuffle is not a permutation", name)
+		}
+	}
+}
+
+func TestShuffleSameSeed(t *testing.T) {
+	lists := newLists(1, 2, 3, 4, 5, 6, 7, 8)
+	a, b := lists["ArrayList"], lists["LinkedList"]
+	collections.Shuffle(a, rand.New(rand.NewPCG(3, 4)))
+	collections.Shuffle(b, rand.New(rand.NewPCG(3, 4)))
+	if !slices.Equal(a.ToArray(), b.ToArray()) {
+		t.Errorf("same seed shuffled to %v and %v", a, b)
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	for name, list := range newLists(1, 3, 5, 7) {
+		if got := collections.BinarySearch(list, 5, nil); got != 2 {
+			t.Errorf("%s: BinarySearch(5) = %d, want 2", name, got)
+		}
+		if got := collections.BinarySearch(list, 4, nil); got != -3 {
+			t.Errorf("%s: BinarySearch(4) = %d, want -3", name, got)
+		}
+		if got := collections.BinarySearch(list, 9, nil); got != -5 {
+			t.Errorf("%s: BinarySearch(9) = %d, want -5", name, got)
+		}
+	}
+}
+
+func TestReversedView(t *testing.T) {
+	for name, list := range newLists(1, 2, 3) {
+		r := collections.Reversed(list)
+		if got := r.ToArray(); !slices.Equal(got, []int{3, 2, 1}) {
+			t.Errorf("%s: Reversed = %v, want [3 2 1]", name, got)
+		}
+		_, listRA := list.(collection.RandomAccess)
+		if _, ok := r.(collection.RandomAccess); ok != listRA {
+			t.Errorf("%s: view implements RandomAccess = %t, want %t", name, ok, listRA)
+		}
+
+		r.Add(0)
+		r.AddAt(0, 4)
+		r.Set(1, 30)
+		if got := list.ToArray(); !slices.Equal(got, []int{0, 1, 2, 30, 4}) {
+			t.Errorf("%s: list = %v after writing through the view, want [0 1 2 30 4]", name, got)
+		}
+		list.RemoveAt(0)
+		if got := r.ToArray(); !slices.Equal(got, []int{4, 30, 2, 1}) {
+			t.Errorf("%s: view = %v after removing from the list, want [4 30 2 1]", name, got)
+		}
+		if r.IndexOf(2) != 2 || r.LastIndexOf(4) != 0 {
+			t.Errorf("%s: IndexOf(2) = %d, LastIndexOf(4) = %d, want 2, 0", name, r.IndexOf(2), r.LastIndexOf(4))
+		}
+		if collections.Reversed(r) != list {
+			t.Errorf("%s: reversing the view did not return the list", name)
+		}
+		if !r.Equals(arraylist.NewFromSlice([]int{4, 30, 2, 1})) {
+			t.Errorf("%s: view %v not equal to [4 30 2 1]", name, r)
+		}
+	}
+}
+
+func TestReversedSort(t *testing.T) {
+	list := linkedlist.NewFromSlice([]int{2, 3, 1})
+	r := collections.Reversed[int](list)
+	r.Sort(nil)
+	if got := list.ToArray(); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("list = %v after sorting the reversed view, want [3 2 1]", got)
+	}
+}
+
+func TestUnmodifiableList(t *testing.T) {
+	list := arraylist.NewFromSlice([]int{1, 2, 3})
+	u := collections.UnmodifiableList[int](list)
+	if _, ok := u.(collection.RandomAccess); !ok {
+		t.Error("view of an ArrayList does not implement RandomAccess")
+	}
+	if collections.UnmodifiableList(u) != u {
+		t.Error("wrapping the view again returned a new view")
+	}
+
+	list.Add(4)
+	if got := u.ToArray(); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("view = %v after adding to the list, want [1 2 3 4]", got)
+	}
+	if u.Get(3) != 4 || !u.Contains(2) || u.Size() != 4 {
+		t.Errorf("Get(3) = %d, Contains(2) = %t, Size() = %d", u.Get(3), u.Contains(2), u.Size())
+	}
+
+	for name, f := range map[string]func(){
+		"Add":        func() { u.Add(5) },
+		"Set":        func() { u.Set(0, 5) },
+		"RemoveAt":   func() { u.RemoveAt(0) },
+		"Clear":      func() { u.Clear() },
+		"RemoveIf":   func() { u.RemoveIf(func(int) bool { return false }) },
+		"Sort":       func() { u.Sort(nil) },
+		"ReplaceAll": func() { u.ReplaceAll(func(v int) int { return v }) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != "Unsupported operation" {
+					t.Errorf("%s: recover() = %v, want Unsupported operation", name, r)
+				}
+			}()
+			f()
+		}()
+	}
+	if got := list.ToArray(); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("list = %v after the rejected modifications, want [1 2 3 4]", got)
+	}
+}
+
+func TestReversedEqualsUsesListEquality(t *testing.T) {
+	list := arraylist.NewFromSlice([]string{"a", "B"}, arraylist.WithEquals(strings.EqualFold))
+	r := collections.Reversed[string](list)
+	if !r.Contains("b") {
+		t.Fatal(`Contains("b") = false, want the equality of the list used`)
+	}
+	if !r.Equals(arraylist.NewFromSlice([]string{"b", "A"})) {
+		t.Error("Equals() = false for elements equal under the equality of the list")
+	}
+	if r.Equals(arraylist.NewFromSlice([]string{"a", "b"})) {
+		t.Error("Equals() = true for elements in the wrong order")
+	}
+}
@@ -0,0 +1,271 @@
+package collections
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// reversedList is a reverse-ordered view of a list.
+type reversedList[E any] struct {
+	list collection.List[E]
+}
+
+// backwarder is implemented by the lists that can iterate over their elements in reverse order efficiently.
+type backwarder[E any] interface {
+	Backward() iter.Seq[E]
+}
+
+// Reversed returns a reverse-ordered view of the specified list.
+// Changes made through the view are written through to the list, and changes to the list are visible in the view.
+// Reversing the view returns the original list.
+// The view supports constant-time access by index, and implements collection.RandomAccess, if the list does.
+// List<E> reversed()
+func Reversed[E any](list collection.List[E]) collection.List[E] {
+	switch r := list.(type) {
+	case *reversedList[E]:
+		return r.list
+	case randomAccessReversedList[E]:
+		return r.list
+	case collection.RandomAccess:
+		return randomAccessReversedList[E]{&reversedList[E]{list: list}}
+	}
+	return &reversedList[E]{list: list}
+}
+
+// randomAccessReversedList is a reverse-ordered view of a list that implements collection.RandomAccess.
+type randomAccessReversedList[E any] struct {
+	*reversedList[E]
+}
+
+// RandomAccess marks this view as supporting constant-time access by index.
+func (r randomAccessReversedList[E]) RandomAccess() {}
+
+// Inserts the specified element at the end of this view, which is the beginning of the list.
+// boolean add(E e)
+func (r *reversedList[E]) Add(item E) bool {
+	r.list.AddAt(0, item)
+	return true
+}
+
+// Inserts the specified element at the specified position in this view.
+// void add(int index, E element)
+func (r *reversedList[E]) AddAt(index int, item E) {
+	if err := util.CheckIndex(index, r.list.Size()+1); err != nil {
+		panic(err.Error())
+	}
+	r.list.AddAt(r.list.Size()-index, item)
+}
+
+// Appends all of the elements in the specified slice to the end of this view, in order.
+// boolean addAll(Collection<? extends E> c)
+func (r *reversedList[E]) AddAll(items []E) bool {
+	return r.AddAllAt(r.list.Size(), items)
+}
+
+// Inserts all of the elements in the specified slice into this view at the specified position, in order.
+// boolean addAll(int index, Collection<? extends E> c)
+func (r *reversedList[E]) AddAllAt(index int, items []E) bool {
+	if err := util.CheckIndex(index, r.list.Size()+1); err != nil {
+		panic(err.Error())
+	}
+	reversed := slices.Clone(items)
+	slices.Reverse(reversed)
+	return r.list.AddAllAt(r.list.Size()-index, reversed)
+}
+
+// Removes all of the elements from this view and the list.
+// void clear()
+func (r *reversedList[E]) Clear() {
+	r.list.Clear()
+}
+
+// Returns true if this view contains the specified element.
+// boolean contains(Object o)
+func (r *reversedList[E]) Contains(item E) bool {
+	return r.list.Contains(item)
+}
+
+// Returns true if this view contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (r *reversedList[E]) ContainsAll(items []E) bool {
+	return r.list.ContainsAll(items)
+}
+
+// Returns true if the other list contains equal elements in the same order as this view.
+// The elements are compared with the equality function of the list, by comparing the list with the reverse of the other list.
+// boolean equals(Object o)
+func (r *reversedList[E]) Equals(other collection.List[E]) bool {
+	return r.list.Equals(Reversed(other))
+}
+
+// Performs the given action for each element of this view, in reverse order of the list.
+// void forEach(Consumer<? super E> action)
+func (r *reversedList[E]) ForEach(action util.Consumer[E]) {
+	for v := range r.All() {
+		action(v)
+	}
+}
+
+// Returns the element at the specified position in this view.
+// E get(int index)
+func (r *reversedList[E]) Get(index int) E {
+	return r.list.Get(r.reverseIndex(index))
+}
+
+// Returns the hash code of this view, computed from the hash codes of its elements in order.
+// int hashCode()
+func (r *reversedList[E]) HashCode() uint64 {
+	return listutil.HashElements(r.All())
+}
+
+// Returns the index of the first occurrence of the specified element in this view, or -1 if this view does not contain the element.
+// int indexOf(Object o)
+func (r *reversedList[E]) IndexOf(item E) int {
+	i := r.list.LastIndexOf(item)
+	if i < 0 {
+		return -1
+	}
+	return r.list.Size() - 1 - i
+}
+
+// Returns the index of the last occurrence of the specified element in this view, or -1 if this view does not contain the element.
+// int lastIndexOf(Object o)
+func (r *reversedList[E]) LastIndexOf(item E) int {
+	i := r.list.IndexOf(item)
+	if i < 0 {
+		return -1
+	}
+	return r.list.Size() - 1 - i
+}
+
+// Returns true if this view contains no elements.
+// boolean isEmpty()
+func (r *reversedList[E]) IsEmpty() bool {
+	return r.list.IsEmpty()
+}
+
+// All returns an iterator over the elements in this view, in reverse order of the list, for use with range-over-func.
+func (r *reversedList[E]) All() iter.Seq[E] {
+	if b, ok := r.list.(backwarder[E]); ok {
+		return b.Backward()
+	}
+	return func(yield func(E) bool) {
+		for i := r.list.Size() - 1; i >= 0; i-- {
+			if !yield(r.list.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Removes the first occurrence of the specified element in this view, which is the last occurrence in the list, if it is present.
+// boolean remove(Object o)
+func (r *reversedList[E]) Remove(item E) bool {
+	i := r.list.LastIndexOf(item)
+	if i < 0 {
+		return false
+	}
+	r.list.RemoveAt(i)
+	return true
+}
+
+// Removes the element at the specified position in this view.
+// E remove(int index)
+func (r *reversedList[E]) RemoveAt(index int) E {
+	return r.list.RemoveAt(r.reverseIndex(index))
+}
+
+// Removes all of this view's elements that are also contained in the specified slice.
+// boolean removeAll(Collection<?> c)
+func (r *reversedList[E]) RemoveAll(items []E) bool {
+	return r.list.RemoveAll(items)
+}
+
+// Removes all of the elements of this view that satisfy the given predicate.
+// boolean removeIf(Predicate<? super E> filter)
+func (r *reversedList[E]) RemoveIf(filter util.Predicate[E]) bool {
+	return r.list.RemoveIf(filter)
+}
+
+// Replaces each element of this view with the result of applying the operator to that element.
+// void replaceAll(UnaryOperator<E> operator)
+func (r *reversedList[E]) ReplaceAll(operator util.UnaryOperator[E]) {
+	r.list.ReplaceAll(operator)
+}
+
+// Retains only the elements in this view that are contained in the specified slice.
+// boolean retainAll(Collection<?> c)
+func (r *reversedList[E]) RetainAll(items []E) bool {
+	return r.list.RetainAll(items)
+}
+
+// Returns the list that this view reverses.
+// List<E> reversed()
+func (r *reversedList[E]) Reversed() collection.List[E] {
+	return r.list
+}
+
+// Replaces the element at the specified position in this view with the specified element.
+// E set(int index, E element)
+func (r *reversedList[E]) Set(index int, item E) E {
+	return r.list.Set(r.reverseIndex(index), item)
+}
+
+// Returns the number of elements in this view.
+// int size()
+func (r *reversedList[E]) Size() int {
+	return r.list.Size()
+}
+
+// Sorts this view stably according to the order induced by the specified comparator, or the natural ordering if it is nil.
+// The list ends up sorted in the reverse order, with equal elements in the reverse of their order in this view.
+// void sort(Comparator<? super E> c)
+func (r *reversedList[E]) Sort(comparator util.Comparator[E]) {
+	if comparator == nil {
+		comparator = util.DefaultComparator[E]()
+	}
+	items := r.ToArray()
+	slices.SortStableFunc(items, comparator)
+	slices.Reverse(items)
+	writeBack(r.list, items)
+}
+
+// Returns an array containing all of the elements in this view, in reverse order of the list.
+// Object[] toArray()
+func (r *reversedList[E]) ToArray() []E {
+	items := r.list.ToArray()
+	slices.Reverse(items)
+	return items
+}
+
+// Returns a string representation of this view, in the form "[e1, e2, e3]".
+// String toString()
+func (r *reversedList[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	i := 0
+	for v := range r.All() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, v)
+		i++
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// reverseIndex returns the index in the list of the element at the index in this view, and panics if it is out of bounds.
+func (r *reversedList[E]) reverseIndex(index int) int {
+	n := r.list.Size()
+	if err := util.CheckIndex(index, n); err != nil {
+		panic(err.Error())
+	}
+	return n - 1 - index
+}
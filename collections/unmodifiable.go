@@ -0,0 +1,192 @@
+package collections
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// unmodifiableList is a read-only view of a list.
+type unmodifiableList[E any] struct {
+	list collection.List[E]
+}
+
+// UnmodifiableList returns a read-only view of the specified list.
+// Query operations read through to the list, so changes to the list are visible in the view,
+// and every method that would modify the list panics with "Unsupported operation".
+// The view implements collection.RandomAccess if the list does.
+// static <T> List<T> unmodifiableList(List<? extends T> list)
+func UnmodifiableList[E any](list collection.List[E]) collection.List[E] {
+	switch list.(type) {
+	case *unmodifiableList[E], randomAccessUnmodifiableList[E]:
+		return list
+	case collection.RandomAccess:
+		return randomAccessUnmodifiableList[E]{&unmodifiableList[E]{list: list}}
+	}
+	return &unmodifiableList[E]{list: list}
+}
+
+// randomAccessUnmodifiableList is a read-only view of a list that implements collection.RandomAccess.
+type randomAccessUnmodifiableList[E any] struct {
+	*unmodifiableList[E]
+}
+
+// RandomAccess marks this view as supporting constant-time access by index.
+func (u randomAccessUnmodifiableList[E]) RandomAccess() {}
+
+// Panics, since this list cannot be modified.
+// boolean add(E e)
+func (u *unmodifiableList[E]) Add(E) bool {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// void add(int index, E element)
+func (u *unmodifiableList[E]) AddAt(int, E) {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// boolean addAll(Collection<? extends E> c)
+func (u *unmodifiableList[E]) AddAll([]E) bool {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// boolean addAll(int index, Collection<? extends E> c)
+func (u *unmodifiableList[E]) AddAllAt(int, []E) bool {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// void clear()
+func (u *unmodifiableList[E]) Clear() {
+	panic("Unsupported operation")
+}
+
+// Returns true if this list contains the specified element.
+// boolean contains(Object o)
+func (u *unmodifiableList[E]) Contains(item E) bool {
+	return u.list.Contains(item)
+}
+
+// Returns true if this list contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (u *unmodifiableList[E]) ContainsAll(items []E) bool {
+	return u.list.ContainsAll(items)
+}
+
+// Returns true if the other list contains equal elements in the same order.
+// boolean equals(Object o)
+func (u *unmodifiableList[E]) Equals(other collection.List[E]) bool {
+	return u.list.Equals(other)
+}
+
+// Performs the given action for each element of this list, in order.
+// void forEach(Consumer<? super E> action)
+func (u *unmodifiableList[E]) ForEach(action util.Consumer[E]) {
+	u.list.ForEach(action)
+}
+
+// Returns the element at the specified position in this list.
+// E get(int index)
+func (u *unmodifiableList[E]) Get(index int) E {
+	return u.list.Get(index)
+}
+
+// Returns the hash code of this list.
+// int hashCode()
+func (u *unmodifiableList[E]) HashCode() uint64 {
+	return u.list.HashCode()
+}
+
+// Returns the index of the first occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int indexOf(Object o)
+func (u *unmodifiableList[E]) IndexOf(item E) int {
+	return u.list.IndexOf(item)
+}
+
+// Returns the index of the last occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int lastIndexOf(Object o)
+func (u *unmodifiableList[E]) LastIndexOf(item E) int {
+	return u.list.LastIndexOf(item)
+}
+
+// Returns true if this list contains no elements.
+// boolean isEmpty()
+func (u *unmodifiableList[E]) IsEmpty() bool {
+	return u.list.IsEmpty()
+}
+
+// All returns an iterator over the elements in this list, in order, for use with range-over-func.
+func (u *unmodifiableList[E]) All() iter.Seq[E] {
+	return u.list.All()
+}
+
+// Panics, since this list cannot be modified.
+// boolean remove(Object o)
+func (u *unmodifiableList[E]) Remove(E) bool {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// E remove(int index)
+func (u *unmodifiableList[E]) RemoveAt(int) E {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// boolean removeAll(Collection<?> c)
+func (u *unmodifiableList[E]) RemoveAll([]E) bool {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// boolean removeIf(Predicate<? super E> filter)
+func (u *unmodifiableList[E]) RemoveIf(util.Predicate[E]) bool {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// boolean retainAll(Collection<?> c)
+func (u *unmodifiableList[E]) RetainAll([]E) bool {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// void replaceAll(UnaryOperator<E> operator)
+func (u *unmodifiableList[E]) ReplaceAll(util.UnaryOperator[E]) {
+	panic("Unsupported operation")
+}
+
+// Panics, since this list cannot be modified.
+// E set(int index, E element)
+func (u *unmodifiableList[E]) Set(int, E) E {
+	panic("Unsupported operation")
+}
+
+// Returns the number of elements in this list.
+// int size()
+func (u *unmodifiableList[E]) Size() int {
+	return u.list.Size()
+}
+
+// Panics, since this list cannot be modified.
+// void sort(Comparator<? super E> c)
+func (u *unmodifiableList[E]) Sort(util.Comparator[E]) {
+	panic("Unsupported operation")
+}
+
+// Returns an array containing all of the elements in this list, in order.
+// Object[] toArray()
+func (u *unmodifiableList[E]) ToArray() []E {
+	return u.list.ToArray()
+}
+
+// Returns a string representation of this list, in the form "[e1, e2, e3]".
+// String toString()
+func (u *unmodifiableList[E]) String() string {
+	return fmt.Sprint(u.list)
+}
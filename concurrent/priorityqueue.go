@@ -0,0 +1,302 @@
+package concurrent
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nsce9806q/javastyle-collection/priorityqueue"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// maxLevel is the highest level of the skiplist.
+const maxLevel = 31
+
+// PriorityQueue is a lock-free concurrent priority queue backed by a skiplist.
+// Offer and Poll never block, and scale with the number of producers and consumers
+// instead of serializing on a global mutex like priorityqueue.SynchronizedPriorityQueue.
+// The queue is quiescently consistent: Poll returns the head of the queue as of some point during the call,
+// and Size is an estimate while other goroutines are modifying the queue.
+type PriorityQueue[E any] struct {
+	head       *node[E]
+	tail       *node[E]
+	comparator util.Comparator[E]
+
+	// seq gives every inserted element a unique key, so that equal elements are kept in insertion order.
+	seq  atomic.Uint64
+	size atomic.Int64
+}
+
+// node is a node of the skiplist.
+type node[E any] struct {
+	item     E
+	seq      uint64
+	topLevel int
+	next     []atomic.Pointer[markableRef[E]]
+
+	// taken is set by the poller that claimed this node, before it is unlinked from the skiplist.
+	taken atomic.Bool
+}
+
+// markableRef is an immutable pair of a successor and a mark telling whether the owner node is being unlinked.
+type markableRef[E any] struct {
+	next   *node[E]
+	marked bool
+}
+
+// New creates a new PriorityQueue with the given options.
+// Only the comparator of the given options is used.
+func New[E any](opts ...priorityqueue.Option[E]) *PriorityQueue[E] {
+	tail := newNode[E](maxLevel, nil)
+	head := newNode(maxLevel, tail)
+	return &PriorityQueue[E]{
+		head:       head,
+		tail:       tail,
+		comparator: priorityqueue.ComparatorOf(opts...),
+	}
+}
+
+// newNode creates a node of the given height whose successor on every level is next.
+func newNode[E any](topLevel int, next *node[E]) *node[E] {
+	n := &node[E]{
+		topLevel: topLevel,
+		next:     make([]atomic.Pointer[markableRef[E]], topLevel+1),
+	}
+	for level := range n.next {
+		n.next[level].Store(&markableRef[E]{next: next})
+	}
+	return n
+}
+
+// compareAndSet atomically sets the reference at the given level to (newNext, newMark)
+// if it is currently (expectedNext, expectedMark).
+func (n *node[E]) compareAndSet(level int, expectedNext, newNext *node[E], expectedMark, newMark bool) bool {
+	ref := n.next[level].Load()
+	if ref.next != expectedNext || ref.marked != expectedMark {
+		return false
+	}
+	if ref.next == newNext && ref.marked == newMark {
+		return true
+	}
+	return n.next[level].CompareAndSwap(ref, &markableRef[E]{next: newNext, marked: newMark})
+}
+
+// Inserts the specified element into this queue.
+// boolean add(E e)
+func (q *PriorityQueue[E]) Add(item E) bool {
+	return q.Offer(item)
+}
+
+// Inserts the specified element into this queue.
+// As the queue is unbounded, this method always returns true.
+// boolean offer(E e)
+func (q *PriorityQueue[E]) Offer(item E) bool {
+	seq := q.seq.Add(1)
+	topLevel := randomLevel()
+	var preds, succs [maxLevel + 1]*node[E]
+
+	for {
+		q.find(item, seq, &preds, &succs)
+		n := newNode[E](topLevel, nil)
+		n.item = item
+		n.seq = seq
+		for level := 0; level <= topLevel; level++ {
+			n.next[level].Store(&markableRef[E]{next: succs[level]})
+		}
+
+		// linking the bottom level makes the element part of the queue
+		if !preds[0].compareAndSet(0, succs[0], n, false, false) {
+			continue
+		}
+		q.size.Add(1)
+
+		// the upper levels only speed up searches
+		for level := 1; level <= topLevel; level++ {
+			for {
+				ref := n.next[level].Load()
+				if ref.marked {
+					// the node is already being unlinked
+					return true
+				}
+				if ref.next != succs[level] && !n.next[level].CompareAndSwap(ref, &markableRef[E]{next: succs[level]}) {
+					continue
+				}
+				if preds[level].compareAndSet(level, succs[level], n, false, false) {
+					break
+				}
+				q.find(item, seq, &preds, &succs)
+			}
+		}
+		return true
+	}
+}
+
+// Retrieves and removes the head of this queue, or returns zero value if this queue is empty.
+// E poll()
+func (q *PriorityQueue[E]) Poll() E {
+	item, _ := q.PollOK()
+	return item
+}
+
+// PollOK retrieves and removes the head of this queue.
+// The boolean result is false if this queue is empty.
+func (q *PriorityQueue[E]) PollOK() (E, bool) {
+	for curr := q.head.next[0].Load().next; curr != q.tail; curr = curr.next[0].Load().next {
+		if !curr.taken.Load() && curr.taken.CompareAndSwap(false, true) {
+			q.size.Add(-1)
+			q.unlink(curr)
+			return curr.item, true
+		}
+	}
+	var zero E
+	return zero, false
+}
+
+// Retrieves, but does not remove, the head of this queue, or returns zero value if this queue is empty.
+// E peek()
+func (q *PriorityQueue[E]) Peek() E {
+	item, _ := q.PeekOK()
+	return item
+}
+
+// PeekOK retrieves, but does not remove, the head of this queue.
+// The boolean result is false if this queue is empty.
+func (q *PriorityQueue[E]) PeekOK() (E, bool) {
+	for curr := q.head.next[0].Load().next; curr != q.tail; curr = curr.next[0].Load().next {
+		if !curr.taken.Load() {
+			return curr.item, true
+		}
+	}
+	var zero E
+	return zero, false
+}
+
+// Removes all of the elements from this queue.
+// Elements inserted concurrently may or may not be removed.
+// void clear()
+func (q *PriorityQueue[E]) Clear() {
+	for {
+		if _, ok := q.PollOK(); !ok {
+			return
+		}
+	}
+}
+
+// Returns the comparator used to order the elements in this queue.
+// Comparator<? super E> comparator()
+func (q *PriorityQueue[E]) Comparator() util.Comparator[E] {
+	return q.comparator
+}
+
+// Returns true if this queue contains no elements.
+// boolean isEmpty()
+func (q *PriorityQueue[E]) IsEmpty() bool {
+	_, ok := q.PeekOK()
+	return !ok
+}
+
+// Returns the number of elements in this queue.
+// The result is only an estimate while other goroutines are modifying the queue.
+// int size()
+func (q *PriorityQueue[E]) Size() int {
+	return int(max(q.size.Load(), 0))
+}
+
+// Returns an array containing the elements in this queue, in priority order.
+// The array is a weakly consistent snapshot, which may miss concurrent modifications.
+// Object[] toArray()
+func (q *PriorityQueue[E]) ToArray() []E {
+	var items []E
+	for curr := q.head.next[0].Load().next; curr != q.tail; curr = curr.next[0].Load().next {
+		if !curr.taken.Load() {
+			items = append(items, curr.item)
+		}
+	}
+	return items
+}
+
+// Returns a string representation of this queue, in the form "[e1, e2, e3]".
+// String toString()
+func (q *PriorityQueue[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, v := range q.ToArray() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, v)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// before reports whether node n sorts strictly before the key (item, seq).
+func (q *PriorityQueue[E]) before(n *node[E], item E, seq uint64) bool {
+	if n == q.head {
+		return true
+	}
+	if n == q.tail {
+		return false
+	}
+	c := q.comparator(n.item, item)
+	return c < 0 || (c == 0 && n.seq < seq)
+}
+
+// find fills preds and succs with the nodes immediately before and at or after the key (item, seq) on every level,
+// unlinking marked nodes along the way. It reports whether a node with the key was found.
+func (q *PriorityQueue[E]) find(item E, seq uint64, preds, succs *[maxLevel + 1]*node[E]) bool {
+retry:
+	for {
+		pred := q.head
+		var curr *node[E]
+		for level := maxLevel; level >= 0; level-- {
+			curr = pred.next[level].Load().next
+			for {
+				ref := curr.next[level].Load()
+				for ref.marked {
+					if !pred.compareAndSet(level, curr, ref.next, false, false) {
+						continue retry
+					}
+					curr = pred.next[level].Load().next
+					ref = curr.next[level].Load()
+				}
+				if !q.before(curr, item, seq) {
+					break
+				}
+				pred = curr
+				curr = ref.next
+			}
+			preds[level] = pred
+			succs[level] = curr
+		}
+		return curr != q.tail && curr.seq == seq
+	}
+}
+
+// unlink marks every level of the taken node n and physically removes it from the skiplist.
+func (q *PriorityQueue[E]) unlink(n *node[E]) {
+	for level := n.topLevel; level >= 1; level-- {
+		for {
+			ref := n.next[level].Load()
+			if ref.marked || n.compareAndSet(level, ref.next, ref.next, false, true) {
+				break
+			}
+		}
+	}
+	for {
+		ref := n.next[0].Load()
+		if ref.marked || n.compareAndSet(0, ref.next, ref.next, false, true) {
+			break
+		}
+	}
+
+	var preds, succs [maxLevel + 1]*node[E]
+	q.find(n.item, n.seq, &preds, &succs)
+}
+
+// randomLevel returns a random level for a new node, where level l is chosen with probability 2^-(l+1).
+func randomLevel() int {
+	return min(bits.TrailingZeros64(rand.Uint64()), maxLevel)
+}
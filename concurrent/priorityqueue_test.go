@@ -0,0 +1,186 @@
+package concurrent
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/nsce9806q/javastyle-collection/priorityqueue"
+)
+
+func TestOrder(t *testing.T) {
+	q := New[int]()
+	for _, v := range []int{5, 1, 4, 1, 3} {
+		q.Offer(v)
+	}
+	if got := q.ToArray(); !slices.Equal(got, []int{1, 1, 3, 4, 5}) {
+		t.Errorf("ToArray() = %v, want [1 1 3 4 5]", got)
+	}
+	if got := q.Peek(); got != 1 {
+		t.Errorf("Peek() = %d, want 1", got)
+	}
+	var polled []int
+	for v, ok := q.PollOK(); ok; v, ok = q.PollOK() {
+		polled = append(polled, v)
+	}
+	if !slices.Equal(polled, []int{1, 1, 3, 4, 5}) {
+		t.Errorf("polled %v, want [1 1 3 4 5]", polled)
+	}
+	if !q.IsEmpty() || q.Size() != 0 {
+		t.Errorf("IsEmpty() = %t, Size() = %d after polling every element", q.IsEmpty(), q.Size())
+	}
+}
+
+func TestEqualElementsInInsertionOrder(t *testing.T) {
+	type task struct {
+		p    int
+		name string
+	}
+	q := New(priorityqueue.WithComparator(func(a, b task) int { return cmp.Compare(a.p, b.p) }))
+	for _, tk := range []task{{1, "a"}, {0, "b"}, {1, "c"}, {1, "d"}} {
+		q.Offer(tk)
+	}
+	var names string
+	for !q.IsEmpty() {
+		names += q.Poll().name
+	}
+	if names != "bacd" {
+		t.Errorf("polled %s, want bacd", names)
+	}
+}
+
+func TestReverseOrder(t *testing.T) {
+	q := New(priorityqueue.WithReverseOrder[int]())
+	q.Offer(1)
+	q.Offer(3)
+	q.Offer(2)
+	if got := q.Poll(); got != 3 {
+		t.Errorf("Poll() = %d, want 3", got)
+	}
+}
+
+// TestConcurrentOfferPoll checks, under the race detector, that concurrent producers and consumers
+// neither lose nor duplicate elements.
+func TestConcurrentOfferPoll(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 2000
+	q := New[int]()
+
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				q.Offer(p*perProducer + i)
+			}
+		}()
+	}
+
+	polled := make([][]int, consumers)
+	done := make(chan struct{})
+	var cwg sync.WaitGroup
+	for c := range consumers {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			for {
+				v, ok := q.PollOK()
+				if ok {
+					polled[c] = append(polled[c], v)
+					continue
+				}
+				select {
+				case <-done:
+					// drain whatever the producers inserted last
+					for v, ok := q.PollOK(); ok; v, ok = q.PollOK() {
+						polled[c] = append(polled[c], v)
+					}
+					return
+				default:
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	cwg.Wait()
+
+	var all []int
+	for _, vs := range polled {
+		all = append(all, vs...)
+	}
+	slices.Sort(all)
+	if len(all) != producers*perProducer {
+		t.Fatalf("polled %d elements, want %d", len(all), producers*perProducer)
+	}
+	for i, v := range all {
+		if v != i {
+			t.Fatalf("element %d missing or duplicated", i)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Errorf("queue holds %v after every element was polled", q)
+	}
+}
+
+// TestConcurrentPollOrder checks that, without concurrent producers, concurrent consumers each poll in priority order.
+func TestConcurrentPollOrder(t *testing.T) {
+	const n, consumers = 10000, 4
+	q := New[int]()
+	for i := n - 1; i >= 0; i-- {
+		q.Offer(i)
+	}
+
+	polled := make([][]int, consumers)
+	var wg sync.WaitGroup
+	for c := range consumers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v, ok := q.PollOK(); ok; v, ok = q.PollOK() {
+				polled[c] = append(polled[c], v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for c, vs := range polled {
+		if !slices.IsSorted(vs) {
+			t.Errorf("consumer %d polled out of order", c)
+		}
+		total += len(vs)
+	}
+	if total != n {
+		t.Errorf("polled %d elements, want %d", total, n)
+	}
+}
+
+func TestConcurrentPeekAndSnapshot(t *testing.T) {
+	q := New[int]()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 1000 {
+			q.Offer(i)
+			if i%3 == 0 {
+				q.Poll()
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 200 {
+			q.Peek()
+			if vs := q.ToArray(); !slices.IsSorted(vs) {
+				t.Errorf("ToArray() = %v, want a sorted snapshot", vs)
+				return
+			}
+			_ = q.String()
+			q.Size()
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,490 @@
+package gaplist
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/collections"
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// GapList is a gap-buffer implementation of a list, for workloads that insert and remove elements in the middle.
+// The elements are kept in a single slice around a gap of unused slots, which is moved to the position of each
+// insertion or removal. Edits near the previous one only shift the elements between the two positions, so a run of
+// edits at a cursor, as in a text editor or when merging logs, costs amortized constant time per element instead of
+// the O(n) shifting of an ArrayList. Elements are accessed by index in constant time.
+type GapList[E any] struct {
+	// buf holds the elements in buf[:gapStart] followed by the elements in buf[gapEnd:].
+	buf      []E
+	gapStart int
+	gapEnd   int
+
+	equals util.Equals[E]
+
+	// hashable is set when the elements are compared with == by default, so they can be looked up in a hash set.
+	hashable bool
+
+	// modCount is the number of structural modifications, which change the size of this list.
+	// Iterations compare it with the value they expect, to fail fast instead of traversing a list modified under them.
+	modCount int
+}
+
+// GapList implements the List interface.
+var _ collection.List[int] = (*GapList[int])(nil)
+
+// Option is a function type that sets the GapList.
+type Option[E any] func(*GapList[E])
+
+// WithCapacity is an option that sets the initial capacity.
+func WithCapacity[E any](initialCapacity int) Option[E] {
+	if initialCapacity < 0 {
+		panic("Illegal capacity")
+	}
+	return func(list *GapList[E]) {
+		list.buf = make([]E, initialCapacity)
+		list.gapEnd = initialCapacity
+	}
+}
+
+// WithEquals is an option that sets the custom equality comparison function used by Contains, IndexOf and Remove.
+// Without it, elements are compared by util.DefaultEquals, which uses == when possible and reflect.DeepEqual otherwise.
+func WithEquals[E any](equals util.Equals[E]) Option[E] {
+	return func(list *GapList[E]) {
+		list.equals = equals
+	}
+}
+
+// New creates a new empty GapList with the given options.
+func New[E any](opts ...Option[E]) *GapList[E] {
+	list := &GapList[E]{}
+	for _, opt := range opts {
+		opt(list)
+	}
+	if list.equals == nil {
+		list.equals = util.DefaultEquals[E]()
+		list.hashable = listutil.Hashable[E]()
+	}
+	return list
+}
+
+// NewFromSlice creates a new GapList containing the elements in the given slice, in order.
+// The slice is copied.
+func NewFromSlice[E any](items []E, opts ...Option[E]) *GapList[E] {
+	list := New(opts...)
+	list.AddAll(items)
+	return list
+}
+
+// Appends the specified element to the end of this list.
+// boolean add(E e)
+func (list *GapList[E]) Add(item E) bool {
+	list.insertAt(list.Size(), item)
+	return true
+}
+
+// Inserts the specified element at the specified position in this list,
+// moving the gap there and shifting only the elements between the gap and the position.
+// void add(int index, E element)
+func (list *GapList[E]) AddAt(index int, item E) {
+	listutil.CheckIndex(index, list.Size()+1)
+	list.insertAt(index, item)
+}
+
+// Appends all of the elements in the specified slice to the end of this list, in order.
+// boolean addAll(Collection<? extends E> c)
+func (list *GapList[E]) AddAll(items []E) bool {
+	list.insertAt(list.Size(), items...)
+	return len(items) > 0
+}
+
+// Inserts all of the elements in the specified slice into this list at the specified position, in order.
+// boolean addAll(int index, Collection<? extends E> c)
+func (list *GapList[E]) AddAllAt(index int, items []E) bool {
+	listutil.CheckIndex(index, list.Size()+1)
+	list.insertAt(index, items...)
+	return len(items) > 0
+}
+
+// Inserts the specified element at the beginning of this list.
+// void addFirst(E e)
+func (list *GapList[E]) AddFirst(item E) {
+	list.insertAt(0, item)
+}
+
+// Appends the specified element to the end of this list.
+// void addLast(E e)
+func (list *GapList[E]) AddLast(item E) {
+	list.insertAt(list.Size(), item)
+}
+
+// Backward returns an iterator over the elements in this list, in reverse order, for use with range-over-func.
+// It panics if the list is structurally modified during the iteration.
+func (list *GapList[E]) Backward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		expected := list.modCount
+		// the elements are located by index, as the gap may move during the iteration
+		for i := list.Size() - 1; i >= 0; i-- {
+			if !yield(list.buf[list.physical(i)]) {
+				return
+			}
+			listutil.CheckModCount(list.modCount, expected)
+		}
+	}
+}
+
+// Returns the capacity of the buffer, that is, the number of elements this list can hold without reallocating.
+func (list *GapList[E]) Capacity() int {
+	return len(list.buf)
+}
+
+// Removes all of the elements from this list.
+// The buffer is kept, so the whole of it becomes the gap.
+// void clear()
+func (list *GapList[E]) Clear() {
+	clear(list.buf)
+	list.gapStart = 0
+	list.gapEnd = len(list.buf)
+	list.modCount++
+}
+
+// Returns true if this list contains the specified element.
+// boolean contains(Object o)
+func (list *GapList[E]) Contains(item E) bool {
+	return list.IndexOf(item) >= 0
+}
+
+// Returns true if this list contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (list *GapList[E]) ContainsAll(items []E) bool {
+	for _, item := range items {
+		if !list.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the other list contains equal elements in the same order, regardless of its implementation.
+// The elements are compared with the equality function of this list.
+// boolean equals(Object o)
+func (list *GapList[E]) Equals(other collection.List[E]) bool {
+	return listutil.EqualElements(list.All(), list.Size(), other, list.equals)
+}
+
+// Performs the given action for each element of this list, in order.
+// void forEach(Consumer<? super E> action)
+func (list *GapList[E]) ForEach(action util.Consumer[E]) {
+	for v := range list.All() {
+		action(v)
+	}
+}
+
+// Returns the element at the specified position in this list.
+// E get(int index)
+func (list *GapList[E]) Get(index int) E {
+	listutil.CheckIndex(index, list.Size())
+	return list.buf[list.physical(index)]
+}
+
+// Returns the first element in this list, and panics if this list is empty.
+// E getFirst()
+func (list *GapList[E]) GetFirst() E {
+	if list.IsEmpty() {
+		panic("No such element")
+	}
+	return list.buf[list.physical(0)]
+}
+
+// Returns the last element in this list, and panics if this list is empty.
+// E getLast()
+func (list *GapList[E]) GetLast() E {
+	if list.IsEmpty() {
+		panic("No such element")
+	}
+	return list.buf[list.physical(list.Size()-1)]
+}
+
+// Returns the hash code of this list, computed from the hash codes of its elements in order as java.util.List specifies.
+// Equal lists have equal hash codes when their elements are compared with the default equality.
+// int hashCode()
+func (list *GapList[E]) HashCode() uint64 {
+	return listutil.HashElements(list.All())
+}
+
+// Returns the index of the first occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int indexOf(Object o)
+func (list *GapList[E]) IndexOf(item E) int {
+	i := 0
+	for v := range list.All() {
+		if list.equals(v, item) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// Returns the index of the last occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int lastIndexOf(Object o)
+func (list *GapList[E]) LastIndexOf(item E) int {
+	i := list.Size() - 1
+	for v := range list.Backward() {
+		if list.equals(v, item) {
+			return i
+		}
+		i--
+	}
+	return -1
+}
+
+// Returns true if this list contains no elements.
+// boolean isEmpty()
+func (list *GapList[E]) IsEmpty() bool {
+	return list.Size() == 0
+}
+
+// All returns an iterator over the elements in this list, in order, for use with range-over-func.
+// It panics if the list is structurally modified during the iteration.
+func (list *GapList[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		expected := list.modCount
+		// the elements are located by index, as the gap may move during the iteration
+		for i := range list.Size() {
+			if !yield(list.buf[list.physical(i)]) {
+				return
+			}
+			listutil.CheckModCount(list.modCount, expected)
+		}
+	}
+}
+
+// Removes the first occurrence of the specified element from this list, if it is present.
+// boolean remove(Object o)
+func (list *GapList[E]) Remove(item E) bool {
+	i := list.IndexOf(item)
+	if i < 0 {
+		return false
+	}
+	list.removeRange(i, i+1)
+	return true
+}
+
+// Removes all of this list's elements that are also contained in the specified slice, in a single pass.
+// boolean removeAll(Collection<?> c)
+func (list *GapList[E]) RemoveAll(items []E) bool {
+	return list.RemoveIf(listutil.Membership(items, list.equals, list.hashable))
+}
+
+// Removes the element at the specified position in this list, by widening the gap over it.
+// Returns the element that was removed.
+// E remove(int index)
+func (list *GapList[E]) RemoveAt(index int) E {
+	listutil.CheckIndex(index, list.Size())
+	item := list.buf[list.physical(index)]
+	list.removeRange(index, index+1)
+	return item
+}
+
+// Removes and returns the first element of this list, and panics if this list is empty.
+// E removeFirst()
+func (list *GapList[E]) RemoveFirst() E {
+	if list.IsEmpty() {
+		panic("No such element")
+	}
+	return list.RemoveAt(0)
+}
+
+// Removes all of the elements of this list that satisfy the given predicate.
+// The gap is moved to the end and the remaining elements are compacted in a single pass, in O(n) time.
+// boolean removeIf(Predicate<? super E> filter)
+func (list *GapList[E]) RemoveIf(filter util.Predicate[E]) bool {
+	items := list.compact()
+	kept := slices.DeleteFunc(items, filter)
+	list.gapStart = len(kept)
+	if len(kept) == len(items) {
+		return false
+	}
+	list.modCount++
+	return true
+}
+
+// Removes and returns the last element of this list, and panics if this list is empty.
+// E removeLast()
+func (list *GapList[E]) RemoveLast() E {
+	if list.IsEmpty() {
+		panic("No such element")
+	}
+	return list.RemoveAt(list.Size() - 1)
+}
+
+// Replaces each element of this list with the result of applying the operator to that element.
+// void replaceAll(UnaryOperator<E> operator)
+func (list *GapList[E]) ReplaceAll(operator util.UnaryOperator[E]) {
+	for i, v := range list.buf[:list.gapStart] {
+		list.buf[i] = operator(v)
+	}
+	for i := list.gapEnd; i < len(list.buf); i++ {
+		list.buf[i] = operator(list.buf[i])
+	}
+}
+
+// Retains only the elements in this list that are contained in the specified slice, in a single pass.
+// boolean retainAll(Collection<?> c)
+func (list *GapList[E]) RetainAll(items []E) bool {
+	contains := listutil.Membership(items, list.equals, list.hashable)
+	return list.RemoveIf(func(v E) bool {
+		return !contains(v)
+	})
+}
+
+// RandomAccess marks this list as supporting constant-time access by index.
+func (list *GapList[E]) RandomAccess() {}
+
+// Returns a reverse-ordered view of this list, as collections.Reversed does.
+// Changes made through the view are written through to this list, and changes to this list are visible in the view.
+// List<E> reversed()
+func (list *GapList[E]) Reversed() collection.List[E] {
+	return collections.Reversed[E](list)
+}
+
+// Replaces the element at the specified position in this list with the specified element.
+// Returns the element previously at the specified position.
+// E set(int index, E element)
+func (list *GapList[E]) Set(index int, item E) E {
+	listutil.CheckIndex(index, list.Size())
+	i := list.physical(index)
+	old := list.buf[i]
+	list.buf[i] = item
+	return old
+}
+
+// Returns the number of elements in this list.
+// int size()
+func (list *GapList[E]) Size() int {
+	return len(list.buf) - (list.gapEnd - list.gapStart)
+}
+
+// Sorts this list according to the order induced by the specified comparator.
+// The sort is stable, so equal elements keep their relative order. If the comparator is nil,
+// the elements are sorted by util.DefaultComparator, which uses their natural ordering.
+// void sort(Comparator<? super E> c)
+func (list *GapList[E]) Sort(comparator util.Comparator[E]) {
+	if comparator == nil {
+		comparator = util.DefaultComparator[E]()
+	}
+	slices.SortStableFunc(list.compact(), comparator)
+}
+
+// SortOrdered sorts the list of an ordered type, such as int64, uint or float32, in its natural ordering.
+func SortOrdered[E cmp.Ordered](list *GapList[E]) {
+	list.Sort(util.NaturalOrder[E]())
+}
+
+// Trims the capacity of the buffer to the number of elements in this list, releasing unused memory.
+// void trimToSize()
+func (list *GapList[E]) TrimToSize() {
+	if list.gapEnd > list.gapStart {
+		list.buf = list.ToArray()
+		list.gapStart = len(list.buf)
+		list.gapEnd = len(list.buf)
+	}
+}
+
+// Returns an array containing all of the elements in this list, in order.
+// Object[] toArray()
+func (list *GapList[E]) ToArray() []E {
+	items := make([]E, 0, list.Size())
+	items = append(items, list.buf[:list.gapStart]...)
+	return append(items, list.buf[list.gapEnd:]...)
+}
+
+// Returns a string representation of this list, in the form "[e1, e2, e3]".
+// String toString()
+func (list *GapList[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	i := 0
+	for v := range list.All() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, v)
+		i++
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// physical returns the index in the buffer of the element at the index in this list.
+func (list *GapList[E]) physical(index int) int {
+	if index < list.gapStart {
+		return index
+	}
+	return index + list.gapEnd - list.gapStart
+}
+
+// moveGap moves the gap so that it starts at the index, shifting the elements between the old and new positions across it.
+func (list *GapList[E]) moveGap(index int) {
+	switch {
+	case index < list.gapStart:
+		n := list.gapStart - index
+		copy(list.buf[list.gapEnd-n:list.gapEnd], list.buf[index:list.gapStart])
+		clear(list.buf[index:min(list.gapStart, list.gapEnd-n)])
+		list.gapStart -= n
+		list.gapEnd -= n
+	case index > list.gapStart:
+		n := index - list.gapStart
+		copy(list.buf[list.gapStart:], list.buf[list.gapEnd:list.gapEnd+n])
+		clear(list.buf[max(list.gapEnd, index) : list.gapEnd+n])
+		list.gapStart += n
+		list.gapEnd += n
+	}
+}
+
+// growGap reallocates the buffer, if necessary, so that the gap can hold at least n elements.
+// The buffer at least doubles on each reallocation, so insertions take amortized constant time.
+func (list *GapList[E]) growGap(n int) {
+	if list.gapEnd-list.gapStart >= n {
+		return
+	}
+	size := list.Size()
+	capacity := max(2*len(list.buf), size+n, 8)
+	buf := make([]E, capacity)
+	copy(buf, list.buf[:list.gapStart])
+	tail := len(list.buf) - list.gapEnd
+	copy(buf[capacity-tail:], list.buf[list.gapEnd:])
+	list.buf = buf
+	list.gapEnd = capacity - tail
+}
+
+// insertAt inserts the elements at the index, which must be in the range [0, size].
+func (list *GapList[E]) insertAt(index int, items ...E) {
+	if len(items) == 0 {
+		return
+	}
+	list.moveGap(index)
+	list.growGap(len(items))
+	list.gapStart += copy(list.buf[list.gapStart:], items)
+	list.modCount++
+}
+
+// removeRange removes the elements between fromIndex, inclusive, and toIndex, exclusive, by widening the gap over them.
+// void removeRange(int fromIndex, int toIndex)
+func (list *GapList[E]) removeRange(fromIndex, toIndex int) {
+	list.moveGap(fromIndex)
+	clear(list.buf[list.gapEnd : list.gapEnd+toIndex-fromIndex])
+	list.gapEnd += toIndex - fromIndex
+	list.modCount++
+}
+
+// compact moves the gap to the end of the buffer and returns the elements, which are then contiguous.
+// The elements may be modified in place, and the slice may be shortened by adjusting gapStart to its new length.
+func (list *GapList[E]) compact() []E {
+	list.moveGap(list.Size())
+	return list.buf[:list.gapStart]
+}
@@ -0,0 +1,215 @@
+package gaplist
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestAllFailsFast(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(list *GapList[int])
+	}{
+		{"Add", func(list *GapList[int]) { list.Add(9) }},
+		{"AddFirst", func(list *GapList[int]) { list.AddFirst(9) }},
+		{"RemoveAt", func(list *GapList[int]) { list.RemoveAt(0) }},
+		{"RemoveIf", func(list *GapList[int]) { list.RemoveIf(func(v int) bool { return v == 3 }) }},
+		{"Clear", func(list *GapList[int]) { list.Clear() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, seq := range []string{"All", "Backward", "ForEach"} {
+				list := NewFromSlice([]int{1, 2, 3, 4, 5})
+				func() {
+					defer func() {
+						if r := recover(); r != "Concurrent modification" {
+							t.Errorf("%s: recovered %v, want Concurrent modification", seq, r)
+						}
+					}()
+					switch seq {
+					case "All":
+						for range list.All() {
+							tt.modify(list)
+						}
+					case "Backward":
+						for range list.Backward() {
+							tt.modify(list)
+						}
+					case "ForEach":
+						list.ForEach(func(int) { tt.modify(list) })
+					}
+				}()
+			}
+		})
+	}
+}
+
+func TestAllAllowsSet(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3})
+	i := 0
+	for v := range list.All() {
+		list.Set(i, v*10)
+		i++
+	}
+	if got := list.ToArray(); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("ToArray() = %v, want [10 20 30]", got)
+	}
+}
+
+func TestAllAfterBreak(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3})
+	for v := range list.All() {
+		if v == 2 {
+			list.Remove(v)
+			break
+		}
+	}
+	if got := list.ToArray(); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("ToArray() = %v, want [1 3]", got)
+	}
+}
+
+// checkGapList fails the test unless the list holds the elements of the model, and its unused slots are cleared
+// so that they do not keep removed elements reachable. The elements of the model must be nonzero.
+func checkGapList(t *testing.T, list *GapList[int], model []int) {
+	t.Helper()
+	if list.gapStart < 0 || list.gapStart > list.gapEnd || list.gapEnd > len(list.buf) {
+		t.Fatalf("gap [%d, %d) out of the buffer of length %d", list.gapStart, list.gapEnd, len(list.buf))
+	}
+	if got := list.ToArray(); !slices.Equal(got, model) {
+		t.Fatalf("ToArray() = %v, want %v", got, model)
+	}
+	for i := range model {
+		if got := list.Get(i); got != model[i] {
+			t.Fatalf("Get(%d) = %d, want %d", i, got, model[i])
+		}
+	}
+	backward := slices.Collect(list.Backward())
+	slices.Reverse(backward)
+	if !slices.Equal(backward, model) {
+		t.Fatalf("Backward() does not yield the reverse of %v", model)
+	}
+	for i, v := range list.buf[list.gapStart:list.gapEnd] {
+		if v != 0 {
+			t.Fatalf("gap slot %d holds %d, want it cleared", list.gapStart+i, v)
+		}
+	}
+}
+
+func TestMoveGap(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+	}{
+		{"left by one", 5, 4},
+		{"left past the gap width", 8, 1},
+		{"to the start", 6, 0},
+		{"right by one", 2, 3},
+		{"right past the gap width", 1, 8},
+		{"to the end", 0, 10},
+		{"in place", 4, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+			list := New(WithCapacity[int](13))
+			list.AddAll(model)
+			list.moveGap(tt.from)
+			checkGapList(t, list, model)
+			list.moveGap(tt.to)
+			if list.gapStart != tt.to || list.gapEnd-list.gapStart != 3 {
+				t.Fatalf("gap = [%d, %d), want it to start at %d with width 3", list.gapStart, list.gapEnd, tt.to)
+			}
+			checkGapList(t, list, model)
+		})
+	}
+}
+
+func TestGrowGapKeepsTail(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	// the buffer is full, so inserting in the middle reallocates it with elements on both sides of the gap
+	list.AddAllAt(3, []int{20, 21, 22, 23, 24, 25, 26, 27, 28, 29})
+	checkGapList(t, list, []int{1, 2, 3, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 4, 5, 6, 7, 8})
+	if tail := len(list.buf) - list.gapEnd; tail != 5 {
+		t.Errorf("%d elements after the gap, want 5", tail)
+	}
+	list.AddAt(13, 30)
+	checkGapList(t, list, []int{1, 2, 3, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 4, 5, 6, 7, 8})
+}
+
+func TestRemoveRange(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8})
+	list.moveGap(2)
+	list.removeRange(5, 7)
+	checkGapList(t, list, []int{1, 2, 3, 4, 5, 8})
+	list.removeRange(0, 2)
+	checkGapList(t, list, []int{3, 4, 5, 8})
+	list.removeRange(1, 4)
+	checkGapList(t, list, []int{3})
+}
+
+func TestCompact(t *testing.T) {
+	list := New[int]()
+	list.AddAll([]int{5, 1, 4})
+	list.AddAt(1, 3)
+	list.AddAt(1, 2) // the gap is in the middle
+	if !list.RemoveIf(func(v int) bool { return v == 3 }) {
+		t.Fatal("RemoveIf() = false, want true")
+	}
+	checkGapList(t, list, []int{5, 2, 1, 4})
+	if list.RemoveIf(func(v int) bool { return v > 10 }) {
+		t.Error("RemoveIf() = true when nothing matched")
+	}
+
+	list.AddAt(2, 7)
+	SortOrdered(list)
+	checkGapList(t, list, []int{1, 2, 4, 5, 7})
+	list.AddAt(0, 9)
+	list.RetainAll([]int{9, 2, 7})
+	checkGapList(t, list, []int{9, 2, 7})
+}
+
+// TestModel applies random edits to a GapList and to a plain slice, and compares them after each edit.
+func TestModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(7, 11))
+	for _, capacity := range []int{0, 1, 16} {
+		list := New(WithCapacity[int](capacity))
+		var model []int
+		next := 1
+		for range 2000 {
+			switch op := r.IntN(10); {
+			case op < 4:
+				i := r.IntN(len(model) + 1)
+				items := make([]int, r.IntN(4)+1)
+				for j := range items {
+					items[j] = next
+					next++
+				}
+				list.AddAllAt(i, items)
+				model = slices.Insert(model, i, items...)
+			case op < 7 && len(model) > 0:
+				i := r.IntN(len(model))
+				if got := list.RemoveAt(i); got != model[i] {
+					t.Fatalf("RemoveAt(%d) = %d, want %d", i, got, model[i])
+				}
+				model = slices.Delete(model, i, i+1)
+			case op == 7 && len(model) > 0:
+				i := r.IntN(len(model))
+				list.Set(i, next)
+				model[i] = next
+				next++
+			case op == 8:
+				div := r.IntN(5) + 2
+				list.RemoveIf(func(v int) bool { return v%div == 0 })
+				model = slices.DeleteFunc(model, func(v int) bool { return v%div == 0 })
+			case op == 9 && len(model) > 0:
+				from := r.IntN(len(model))
+				to := from + r.IntN(len(model)-from+1)
+				list.removeRange(from, to)
+				model = slices.Delete(model, from, to)
+			}
+			checkGapList(t, list, model)
+		}
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/nsce9806q/javastyle-collection
 
-go 1.22
+go 1.24
@@ -0,0 +1,205 @@
+package hashmap
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// HashMap is a hash table implementation of a map.
+// It mirrors java.util.HashMap on top of a Go map: Put, Get, Remove and ContainsKey run in constant time on average,
+// and the mappings are iterated in no particular order, which may change from one iteration to the next.
+// The zero value is not usable; create a HashMap with New.
+type HashMap[K comparable, V any] struct {
+	entries map[K]V
+
+	// valueEquals compares values in ContainsValue.
+	valueEquals util.Equals[V]
+}
+
+// HashMap implements the Map interface.
+var _ collection.Map[string, int] = (*HashMap[string, int])(nil)
+
+// Option is a function type that sets the HashMap.
+type Option[K comparable, V any] func(*HashMap[K, V])
+
+// WithCapacity is an option that sets the initial capacity, the number of mappings the map can hold without growing.
+func WithCapacity[K comparable, V any](initialCapacity int) Option[K, V] {
+	if initialCapacity < 0 {
+		panic("Illegal capacity")
+	}
+	return func(m *HashMap[K, V]) {
+		m.entries = make(map[K]V, initialCapacity)
+	}
+}
+
+// WithValueEquals is an option that sets the custom equality comparison function used by ContainsValue.
+// Without it, values are compared by util.DefaultEquals, which uses == when possible and reflect.DeepEqual otherwise.
+func WithValueEquals[K comparable, V any](equals util.Equals[V]) Option[K, V] {
+	return func(m *HashMap[K, V]) {
+		m.valueEquals = equals
+	}
+}
+
+// New creates a new empty HashMap with the given options.
+func New[K comparable, V any](opts ...Option[K, V]) *HashMap[K, V] {
+	m := &HashMap[K, V]{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.entries == nil {
+		m.entries = make(map[K]V)
+	}
+	if m.valueEquals == nil {
+		m.valueEquals = util.DefaultEquals[V]()
+	}
+	return m
+}
+
+// NewFromMap creates a new HashMap containing the mappings of the given Go map.
+// The map is copied.
+func NewFromMap[K comparable, V any](entries map[K]V, opts ...Option[K, V]) *HashMap[K, V] {
+	m := New(append([]Option[K, V]{WithCapacity[K, V](len(entries))}, opts...)...)
+	maps.Copy(m.entries, entries)
+	return m
+}
+
+// Removes all of the mappings from this map.
+// void clear()
+func (m *HashMap[K, V]) Clear() {
+	clear(m.entries)
+}
+
+// Returns true if this map contains a mapping for the specified key.
+// boolean containsKey(Object key)
+func (m *HashMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Returns true if this map maps one or more keys to the specified value, in O(n) time.
+// The values are compared with the value equality function of this map.
+// boolean containsValue(Object value)
+func (m *HashMap[K, V]) ContainsValue(value V) bool {
+	for _, v := range m.entries {
+		if m.valueEquals(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Performs the given action for each mapping in this map, in no particular order.
+// void forEach(BiConsumer<? super K,? super V> action)
+func (m *HashMap[K, V]) ForEach(action util.BiConsumer[K, V]) {
+	for k, v := range m.entries {
+		action(k, v)
+	}
+}
+
+// Returns the value to which the specified key is mapped, or the zero value if this map contains no mapping for the key.
+// V get(Object key)
+func (m *HashMap[K, V]) Get(key K) V {
+	return m.entries[key]
+}
+
+// GetOK returns the value to which the specified key is mapped, and whether this map contains a mapping for the key.
+func (m *HashMap[K, V]) GetOK(key K) (V, bool) {
+	v, ok := m.entries[key]
+	return v, ok
+}
+
+// Returns the value to which the specified key is mapped, or defaultValue if this map contains no mapping for the key.
+// V getOrDefault(Object key, V defaultValue)
+func (m *HashMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if v, ok := m.entries[key]; ok {
+		return v
+	}
+	return defaultValue
+}
+
+// Returns true if this map contains no mappings.
+// boolean isEmpty()
+func (m *HashMap[K, V]) IsEmpty() bool {
+	return len(m.entries) == 0
+}
+
+// All returns an iterator over the mappings in this map, in no particular order, for use with range-over-func.
+func (m *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return maps.All(m.entries)
+}
+
+// Keys returns an iterator over the keys in this map, in no particular order, for use with range-over-func.
+// Set<K> keySet()
+func (m *HashMap[K, V]) Keys() iter.Seq[K] {
+	return maps.Keys(m.entries)
+}
+
+// Values returns an iterator over the values in this map, in no particular order, for use with range-over-func.
+// Collection<V> values()
+func (m *HashMap[K, V]) Values() iter.Seq[V] {
+	return maps.Values(m.entries)
+}
+
+// Associates the specified value with the specified key in this map, replacing any previous value.
+// Returns the previous value associated with the key, or the zero value if there was no mapping for the key.
+// V put(K key, V value)
+func (m *HashMap[K, V]) Put(key K, value V) V {
+	old := m.entries[key]
+	m.entries[key] = value
+	return old
+}
+
+// Copies all of the mappings from the specified Go map to this map, replacing the values of keys already mapped.
+// void putAll(Map<? extends K,? extends V> m)
+func (m *HashMap[K, V]) PutAll(entries map[K]V) {
+	maps.Copy(m.entries, entries)
+}
+
+// Associates the specified value with the specified key if the key is not already mapped.
+// Returns the current value associated with the key, or the zero value if there was no mapping for the key.
+// V putIfAbsent(K key, V value)
+func (m *HashMap[K, V]) PutIfAbsent(key K, value V) V {
+	if v, ok := m.entries[key]; ok {
+		return v
+	}
+	m.entries[key] = value
+	var zero V
+	return zero
+}
+
+// Removes the mapping for a key from this map if it is present.
+// Returns the value previously associated with the key, or the zero value if there was no mapping for the key.
+// V remove(Object key)
+func (m *HashMap[K, V]) Remove(key K) V {
+	old := m.entries[key]
+	delete(m.entries, key)
+	return old
+}
+
+// Returns the number of mappings in this map.
+// int size()
+func (m *HashMap[K, V]) Size() int {
+	return len(m.entries)
+}
+
+// Returns a string representation of this map, in the form "{k1=v1, k2=v2}", in no particular order.
+// String toString()
+func (m *HashMap[K, V]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	i := 0
+	for k, v := range m.entries {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%v=%v", k, v)
+		i++
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
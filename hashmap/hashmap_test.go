@@ -0,0 +1,99 @@
+package hashmap
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestPutGetRemove(t *testing.T) {
+	m := New[string, int]()
+	if old := m.Put("a", 1); old != 0 {
+		t.Errorf(`Put("a", 1) = %d, want 0`, old)
+	}
+	if old := m.Put("a", 2); old != 1 {
+		t.Errorf(`Put("a", 2) = %d, want 1`, old)
+	}
+	if v, ok := m.GetOK("a"); !ok || v != 2 {
+		t.Errorf(`GetOK("a") = %d, %t, want 2, true`, v, ok)
+	}
+	if _, ok := m.GetOK("b"); ok {
+		t.Error(`GetOK("b") = true on a missing key`)
+	}
+	if got := m.GetOrDefault("b", 7); got != 7 {
+		t.Errorf(`GetOrDefault("b", 7) = %d, want 7`, got)
+	}
+	if old := m.Remove("a"); old != 2 {
+		t.Errorf(`Remove("a") = %d, want 2`, old)
+	}
+	if !m.IsEmpty() || m.ContainsKey("a") {
+		t.Errorf("map is %v after removing its only key, want {}", m)
+	}
+}
+
+func TestPutIfAbsent(t *testing.T) {
+	m := NewFromMap(map[string]int{"a": 1})
+	if got := m.PutIfAbsent("a", 5); got != 1 {
+		t.Errorf(`PutIfAbsent("a", 5) = %d, want 1`, got)
+	}
+	if got := m.PutIfAbsent("b", 5); got != 0 {
+		t.Errorf(`PutIfAbsent("b", 5) = %d, want 0`, got)
+	}
+	if got := m.Get("a"); got != 1 {
+		t.Errorf(`Get("a") = %d, want 1`, got)
+	}
+	if got := m.Get("b"); got != 5 {
+		t.Errorf(`Get("b") = %d, want 5`, got)
+	}
+}
+
+func TestNewFromMapCopies(t *testing.T) {
+	src := map[string]int{"a": 1, "b": 2}
+	m := NewFromMap(src)
+	src["c"] = 3
+	m.PutAll(map[string]int{"b": 20, "d": 4})
+
+	want := map[string]int{"a": 1, "b": 20, "d": 4}
+	if got := maps.Collect(m.All()); !maps.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if got := slices.Sorted(m.Keys()); !slices.Equal(got, []string{"a", "b", "d"}) {
+		t.Errorf("Keys() = %v, want [a b d]", got)
+	}
+	if len(src) != 3 {
+		t.Errorf("source map modified: %v", src)
+	}
+}
+
+func TestContainsValue(t *testing.T) {
+	m := NewFromMap(map[string][]int{"a": {1, 2}})
+	if !m.ContainsValue([]int{1, 2}) {
+		t.Error("ContainsValue([1 2]) = false, want values compared by DefaultEquals")
+	}
+
+	byLen := New(WithValueEquals[string](func(a, b []int) bool { return len(a) == len(b) }))
+	byLen.Put("a", []int{1, 2})
+	if !byLen.ContainsValue([]int{3, 4}) {
+		t.Error("ContainsValue([3 4]) = false, want the custom equality used")
+	}
+}
+
+func TestClearAndString(t *testing.T) {
+	m := NewFromMap(map[int]string{1: "x"})
+	if got := m.String(); got != "{1=x}" {
+		t.Errorf("String() = %s, want {1=x}", got)
+	}
+	m.Clear()
+	if m.Size() != 0 || m.String() != "{}" {
+		t.Errorf("map is %v after Clear, want {}", m)
+	}
+}
+
+func TestNegativeCapacityPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("WithCapacity(-1) did not panic")
+		}
+	}()
+	New(WithCapacity[string, int](-1))
+}
@@ -0,0 +1,141 @@
+package immutable
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// List is a read-only list of elements.
+// It has no mutating methods, and is safe for concurrent use by multiple goroutines.
+// The zero value is an empty list.
+type List[E any] struct {
+	items []E
+}
+
+// ListOf returns a list containing the given elements, in order.
+// The elements are copied, so later modifications of a slice passed with ... are not reflected in the list.
+// static <E> List<E> of(E... elements)
+func ListOf[E any](items ...E) List[E] {
+	return List[E]{items: slices.Clip(slices.Clone(items))}
+}
+
+// ListCopyOf returns a list containing the elements of the given sequence, in iteration order,
+// such as the All method of another collection.
+// static <E> List<E> copyOf(Collection<? extends E> coll)
+func ListCopyOf[E any](src iter.Seq[E]) List[E] {
+	return List[E]{items: slices.Clip(slices.Collect(src))}
+}
+
+// Returns true if this list contains the specified element.
+// The elements are compared by util.DefaultEquals.
+// boolean contains(Object o)
+func (list List[E]) Contains(item E) bool {
+	return list.IndexOf(item) >= 0
+}
+
+// Returns true if this list contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (list List[E]) ContainsAll(items []E) bool {
+	for _, item := range items {
+		if !list.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Performs the given action for each element of this list, in order.
+// void forEach(Consumer<? super E> action)
+func (list List[E]) ForEach(action util.Consumer[E]) {
+	for _, v := range list.items {
+		action(v)
+	}
+}
+
+// Returns the element at the specified position in this list.
+// E get(int index)
+func (list List[E]) Get(index int) E {
+	if err := util.CheckIndex(index, len(list.items)); err != nil {
+		panic(err.Error())
+	}
+	return list.items[index]
+}
+
+// Returns the index of the first occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// The elements are compared by util.DefaultEquals.
+// int indexOf(Object o)
+func (list List[E]) IndexOf(item E) int {
+	equals := util.DefaultEquals[E]()
+	for i, v := range list.items {
+		if equals(v, item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the index of the last occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// The elements are compared by util.DefaultEquals.
+// int lastIndexOf(Object o)
+func (list List[E]) LastIndexOf(item E) int {
+	equals := util.DefaultEquals[E]()
+	for i := len(list.items) - 1; i >= 0; i-- {
+		if equals(list.items[i], item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns true if this list contains no elements.
+// boolean isEmpty()
+func (list List[E]) IsEmpty() bool {
+	return len(list.items) == 0
+}
+
+// All returns an iterator over the elements in this list, in order, for use with range-over-func.
+func (list List[E]) All() iter.Seq[E] {
+	return slices.Values(list.items)
+}
+
+// Returns the number of elements in this list.
+// int size()
+func (list List[E]) Size() int {
+	return len(list.items)
+}
+
+// Returns the portion of this list between fromIndex, inclusive, and toIndex, exclusive.
+// The returned list shares the elements of this list, which is safe since neither can be modified.
+// List<E> subList(int fromIndex, int toIndex)
+func (list List[E]) SubList(fromIndex, toIndex int) List[E] {
+	if err := util.CheckFromToIndex(fromIndex, toIndex, len(list.items)); err != nil {
+		panic(err.Error())
+	}
+	return List[E]{items: list.items[fromIndex:toIndex:toIndex]}
+}
+
+// Returns an array containing all of the elements in this list, in order.
+// The array is a copy, so modifying it does not affect this list.
+// Object[] toArray()
+func (list List[E]) ToArray() []E {
+	return slices.Clone(list.items)
+}
+
+// Returns a string representation of this list, in the form "[e1, e2, e3]".
+// String toString()
+func (list List[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, v := range list.items {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, v)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
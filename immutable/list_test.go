@@ -0,0 +1,53 @@
+package immutable
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestListOfCopies(t *testing.T) {
+	items := []int{1, 2, 3}
+	list := ListOf(items...)
+	items[0] = 9
+	if got := list.Get(0); got != 1 {
+		t.Errorf("Get(0) = %d after modifying the source slice, want 1", got)
+	}
+
+	arr := list.ToArray()
+	arr[1] = 9
+	if got := list.Get(1); got != 2 {
+		t.Errorf("Get(1) = %d after modifying ToArray(), want 2", got)
+	}
+}
+
+func TestListSubList(t *testing.T) {
+	list := ListCopyOf(slices.Values([]string{"a", "b", "c", "d"}))
+	sub := list.SubList(1, 3)
+	if got := sub.String(); got != "[b, c]" {
+		t.Errorf("SubList(1, 3) = %s, want [b, c]", got)
+	}
+	if got := sub.IndexOf("d"); got != -1 {
+		t.Errorf(`IndexOf("d") = %d on the sublist, want -1`, got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SubList(3, 1) did not panic")
+		}
+	}()
+	list.SubList(3, 1)
+}
+
+func TestListLookup(t *testing.T) {
+	list := ListOf(1, 2, 1)
+	if list.IndexOf(1) != 0 || list.LastIndexOf(1) != 2 || list.IndexOf(3) != -1 {
+		t.Errorf("IndexOf(1) = %d, LastIndexOf(1) = %d, IndexOf(3) = %d", list.IndexOf(1), list.LastIndexOf(1), list.IndexOf(3))
+	}
+	if !list.ContainsAll([]int{2, 1}) || list.Contains(3) {
+		t.Error("Contains or ContainsAll returned the wrong result")
+	}
+	var zero List[int]
+	if !zero.IsEmpty() || zero.Size() != 0 || zero.String() != "[]" {
+		t.Errorf("zero List is %s, want []", zero)
+	}
+}
@@ -0,0 +1,335 @@
+package immutable
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+const (
+	// vectorBits is the number of index bits consumed by each level of the trie of a Vector.
+	vectorBits = 5
+
+	// vectorWidth is the number of children of a node, and of elements of a leaf, of the trie of a Vector.
+	vectorWidth = 1 << vectorBits
+
+	// vectorMask extracts the index of a child from the index bits of one level.
+	vectorMask = vectorWidth - 1
+)
+
+// Vector is a persistent list of elements, implemented as a bit-partitioned trie with a tail, as in Clojure.
+// Add, Set and RemoveLast return a new vector that shares all but O(log n) of its structure with the original,
+// which is left unchanged, so taking snapshots is free. Get, Set, Add and RemoveLast run in O(log32 n) time,
+// which is effectively constant. It is safe for concurrent use by multiple goroutines.
+// The zero value is an empty vector.
+type Vector[E any] struct {
+	size int
+
+	// shift is the number of index bits below the root, a multiple of vectorBits, or 0 when root is nil.
+	shift int
+
+	// root holds the elements before the tail, in leaves of vectorWidth elements at depth shift/vectorBits.
+	root *vectorNode[E]
+
+	// tail holds the last 1 to vectorWidth elements, so that appending only copies it until it is full.
+	tail []E
+}
+
+// vectorNode is a node of the trie of a Vector: an internal node with children, or a leaf with elements.
+// Nodes are never modified once they are reachable from a vector.
+type vectorNode[E any] struct {
+	children []*vectorNode[E]
+	items    []E
+}
+
+// VectorOf returns a vector containing the given elements, in order.
+// static <E> List<E> of(E... elements)
+func VectorOf[E any](items ...E) Vector[E] {
+	return VectorCopyOf(slices.Values(items))
+}
+
+// VectorCopyOf returns a vector containing the elements of the given sequence, in iteration order,
+// such as the All method of another collection.
+// static <E> List<E> copyOf(Collection<? extends E> coll)
+func VectorCopyOf[E any](src iter.Seq[E]) Vector[E] {
+	var v Vector[E]
+	for item := range src {
+		v = v.Add(item)
+	}
+	return v
+}
+
+// Add returns a new vector with the specified element appended to the end of this vector.
+func (v Vector[E]) Add(item E) Vector[E] {
+	if len(v.tail) < vectorWidth {
+		tail := make([]E, len(v.tail)+1)
+		copy(tail, v.tail)
+		tail[len(v.tail)] = item
+		return Vector[E]{size: v.size + 1, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	leaf := &vectorNode[E]{items: v.tail}
+	root, shift := v.root, v.shift
+	switch {
+	case root == nil:
+		root, shift = &vectorNode[E]{children: []*vectorNode[E]{leaf}}, vectorBits
+	case v.size>>vectorBits > 1<<shift:
+		// The trie is full, so it grows a new root.
+		root = &vectorNode[E]{children: []*vectorNode[E]{root, newVectorPath(shift, leaf)}}
+		shift += vectorBits
+	default:
+		root = v.pushTail(shift, root, leaf)
+	}
+	return Vector[E]{size: v.size + 1, shift: shift, root: root, tail: []E{item}}
+}
+
+// AddAll returns a new vector with the specified elements appended to the end of this vector, in order.
+func (v Vector[E]) AddAll(items ...E) Vector[E] {
+	for _, item := range items {
+		v = v.Add(item)
+	}
+	return v
+}
+
+// Set returns a new vector with the element at the specified position replaced by the specified element.
+// Only the nodes on the path to the element are copied.
+func (v Vector[E]) Set(index int, item E) Vector[E] {
+	listutil.CheckIndex(index, v.size)
+	if index >= v.tailOffset() {
+		tail := slices.Clone(v.tail)
+		tail[index-v.tailOffset()] = item
+		v.tail = tail
+		return v
+	}
+	v.root = setVectorPath(v.shift, v.root, index, item)
+	return v
+}
+
+// RemoveLast returns a new vector without the last element of this vector, and panics if this vector is empty.
+func (v Vector[E]) RemoveLast() Vector[E] {
+	switch {
+	case v.size == 0:
+		panic("No such element")
+	case v.size == 1:
+		return Vector[E]{}
+	case len(v.tail) > 1:
+		v.tail = v.tail[: len(v.tail)-1 : len(v.tail)-1]
+		v.size--
+		return v
+	}
+
+	// The tail becomes empty, so the last leaf of the trie becomes the tail.
+	tail := v.leafFor(v.size - 2)
+	root, shift := v.popTail(v.shift, v.root), v.shift
+	switch {
+	case root == nil:
+		shift = 0
+	case shift > vectorBits && len(root.children) == 1:
+		root = root.children[0]
+		shift -= vectorBits
+	}
+	return Vector[E]{size: v.size - 1, shift: shift, root: root, tail: tail}
+}
+
+// Returns true if this vector contains the specified element.
+// The elements are compared by util.DefaultEquals.
+// boolean contains(Object o)
+func (v Vector[E]) Contains(item E) bool {
+	return v.IndexOf(item) >= 0
+}
+
+// Returns true if this vector contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (v Vector[E]) ContainsAll(items []E) bool {
+	for _, item := range items {
+		if !v.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Performs the given action for each element of this vector, in order.
+// void forEach(Consumer<? super E> action)
+func (v Vector[E]) ForEach(action util.Consumer[E]) {
+	for item := range v.All() {
+		action(item)
+	}
+}
+
+// Returns the element at the specified position in this vector.
+// E get(int index)
+func (v Vector[E]) Get(index int) E {
+	listutil.CheckIndex(index, v.size)
+	if index >= v.tailOffset() {
+		return v.tail[index-v.tailOffset()]
+	}
+	return v.leafFor(index)[index&vectorMask]
+}
+
+// Returns the index of the first occurrence of the specified element in this vector, or -1 if this vector does not contain the element.
+// The elements are compared by util.DefaultEquals.
+// int indexOf(Object o)
+func (v Vector[E]) IndexOf(item E) int {
+	equals := util.DefaultEquals[E]()
+	i := 0
+	for w := range v.All() {
+		if equals(w, item) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// Returns the index of the last occurrence of the specified element in this vector, or -1 if this vector does not contain the element.
+// The elements are compared by util.DefaultEquals.
+// int lastIndexOf(Object o)
+func (v Vector[E]) LastIndexOf(item E) int {
+	equals := util.DefaultEquals[E]()
+	for i := v.size - 1; i >= 0; i-- {
+		if equals(v.Get(i), item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns true if this vector contains no elements.
+// boolean isEmpty()
+func (v Vector[E]) IsEmpty() bool {
+	return v.size == 0
+}
+
+// All returns an iterator over the elements in this vector, in order, for use with range-over-func.
+// It visits the elements a leaf at a time, without walking the trie for each of them.
+func (v Vector[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for i := 0; i < v.tailOffset(); i += vectorWidth {
+			for _, item := range v.leafFor(i) {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+		for _, item := range v.tail {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the number of elements in this vector.
+// int size()
+func (v Vector[E]) Size() int {
+	return v.size
+}
+
+// Returns an array containing all of the elements in this vector, in order.
+// The array is a copy, so modifying it does not affect this vector.
+// Object[] toArray()
+func (v Vector[E]) ToArray() []E {
+	items := make([]E, 0, v.size)
+	for i := 0; i < v.tailOffset(); i += vectorWidth {
+		items = append(items, v.leafFor(i)...)
+	}
+	return append(items, v.tail...)
+}
+
+// Returns a string representation of this vector, in the form "[e1, e2, e3]".
+// String toString()
+func (v Vector[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	i := 0
+	for item := range v.All() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, item)
+		i++
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// tailOffset returns the index of the first element of the tail.
+func (v Vector[E]) tailOffset() int {
+	return v.size - len(v.tail)
+}
+
+// leafFor returns the elements of the leaf of the trie that holds the element at the index, which is before the tail.
+func (v Vector[E]) leafFor(index int) []E {
+	n := v.root
+	for level := v.shift; level > 0; level -= vectorBits {
+		n = n.children[(index>>level)&vectorMask]
+	}
+	return n.items
+}
+
+// pushTail returns a copy of the node at the level with the full leaf appended as the last leaf below it,
+// on the path to the element at the index size-1 of this vector.
+func (v Vector[E]) pushTail(level int, parent, leaf *vectorNode[E]) *vectorNode[E] {
+	i := ((v.size - 1) >> level) & vectorMask
+	children := make([]*vectorNode[E], max(len(parent.children), i+1))
+	copy(children, parent.children)
+	switch {
+	case level == vectorBits:
+		children[i] = leaf
+	case i < len(parent.children):
+		children[i] = v.pushTail(level-vectorBits, parent.children[i], leaf)
+	default:
+		children[i] = newVectorPath(level-vectorBits, leaf)
+	}
+	return &vectorNode[E]{children: children}
+}
+
+// popTail returns a copy of the node at the level without its last leaf, which holds the element at the index size-2
+// of this vector, or nil if the node becomes empty.
+func (v Vector[E]) popTail(level int, n *vectorNode[E]) *vectorNode[E] {
+	i := ((v.size - 2) >> level) & vectorMask
+	if level > vectorBits {
+		child := v.popTail(level-vectorBits, n.children[i])
+		if child == nil && i == 0 {
+			return nil
+		}
+		children := slices.Clone(n.children[:i+1])
+		if child == nil {
+			children = children[:i]
+		} else {
+			children[i] = child
+		}
+		return &vectorNode[E]{children: children}
+	}
+	if i == 0 {
+		return nil
+	}
+	return &vectorNode[E]{children: slices.Clone(n.children[:i])}
+}
+
+// newVectorPath returns a chain of single-child nodes from the level down to the leaf.
+func newVectorPath[E any](level int, leaf *vectorNode[E]) *vectorNode[E] {
+	if level == 0 {
+		return leaf
+	}
+	return &vectorNode[E]{children: []*vectorNode[E]{newVectorPath(level-vectorBits, leaf)}}
+}
+
+// setVectorPath returns a copy of the node at the level with the element at the index replaced,
+// copying only the nodes on the path to it.
+func setVectorPath[E any](level int, n *vectorNode[E], index int, item E) *vectorNode[E] {
+	if level == 0 {
+		items := slices.Clone(n.items)
+		items[index&vectorMask] = item
+		return &vectorNode[E]{items: items}
+	}
+	children := slices.Clone(n.children)
+	i := (index >> level) & vectorMask
+	children[i] = setVectorPath(level-vectorBits, children[i], index, item)
+	return &vectorNode[E]{children: children}
+}
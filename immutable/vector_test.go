@@ -0,0 +1,135 @@
+package immutable
+
+import (
+	"slices"
+	"testing"
+)
+
+// vectorSizes straddle the boundaries where the tail fills and the trie grows a level.
+var vectorSizes = []int{0, 1, 31, 32, 33, 64, 1056, 1057, 1088, 33824, 33825, 40000}
+
+func TestVectorAddGet(t *testing.T) {
+	for _, n := range vectorSizes {
+		var v Vector[int]
+		for i := range n {
+			v = v.Add(i)
+		}
+		if v.Size() != n {
+			t.Fatalf("n=%d: Size() = %d", n, v.Size())
+		}
+		for i := range n {
+			if got := v.Get(i); got != i {
+				t.Fatalf("n=%d: Get(%d) = %d", n, i, got)
+			}
+		}
+		i := 0
+		for item := range v.All() {
+			if item != i {
+				t.Fatalf("n=%d: All() yielded %d at %d", n, item, i)
+			}
+			i++
+		}
+		if i != n {
+			t.Fatalf("n=%d: All() yielded %d elements", n, i)
+		}
+	}
+}
+
+func TestVectorRemoveLast(t *testing.T) {
+	for _, n := range vectorSizes[1:] {
+		v := VectorCopyOf(slices.Values(make([]int, n)))
+		for i := range n {
+			v = v.Set(i, i)
+		}
+		for size := n - 1; size >= 0; size-- {
+			v = v.RemoveLast()
+			if v.Size() != size {
+				t.Fatalf("n=%d: Size() = %d, want %d", n, v.Size(), size)
+			}
+			if size > 0 && v.Get(size-1) != size-1 {
+				t.Fatalf("n=%d: Get(%d) = %d after RemoveLast", n, size-1, v.Get(size-1))
+			}
+		}
+		// the emptied vector grows again like a new one
+		v = v.AddAll(1, 2, 3)
+		if got := v.ToArray(); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Fatalf("n=%d: ToArray() = %v, want [1 2 3]", n, got)
+		}
+	}
+}
+
+func TestVectorPersistence(t *testing.T) {
+	const n = 2000
+	v := VectorCopyOf(func(yield func(int) bool) {
+		for i := range n {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	snapshot := v.ToArray()
+
+	set := v.Set(0, -1).Set(n-1, -1).Set(n/2, -1)
+	added := v.Add(n)
+	removed := v.RemoveLast()
+	if got := v.ToArray(); !slices.Equal(got, snapshot) {
+		t.Fatal("original vector changed after Set, Add and RemoveLast")
+	}
+	if set.Get(0) != -1 || set.Get(n/2) != -1 || set.Get(n-1) != -1 || set.Get(1) != 1 {
+		t.Errorf("Set() did not replace the elements")
+	}
+	if added.Size() != n+1 || added.Get(n) != n {
+		t.Errorf("Add() = size %d, last %d", added.Size(), added.Get(added.Size()-1))
+	}
+	if removed.Size() != n-1 {
+		t.Errorf("RemoveLast() size = %d, want %d", removed.Size(), n-1)
+	}
+
+	// branches from the same vector do not see each other's tails
+	a, b := v.Add(1), v.Add(2)
+	if a.Get(n) != 1 || b.Get(n) != 2 {
+		t.Errorf("branches share a tail: %d, %d", a.Get(n), b.Get(n))
+	}
+}
+
+func TestVectorLookup(t *testing.T) {
+	v := VectorOf("a", "b", "c", "b")
+	if got := v.IndexOf("b"); got != 1 {
+		t.Errorf(`IndexOf("b") = %d, want 1`, got)
+	}
+	if got := v.LastIndexOf("b"); got != 3 {
+		t.Errorf(`LastIndexOf("b") = %d, want 3`, got)
+	}
+	if v.Contains("d") || !v.ContainsAll([]string{"a", "c"}) {
+		t.Error("Contains or ContainsAll returned the wrong result")
+	}
+	if got := v.String(); got != "[a, b, c, b]" {
+		t.Errorf("String() = %s, want [a, b, c, b]", got)
+	}
+	var zero Vector[string]
+	if !zero.IsEmpty() || zero.String() != "[]" {
+		t.Errorf("zero Vector is %s, want []", zero)
+	}
+}
+
+func TestVectorPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"Get out of range", func() { VectorOf(1, 2).Get(2) }},
+		{"Get negative", func() { VectorOf(1, 2).Get(-1) }},
+		{"Set out of range", func() { VectorOf(1, 2).Set(2, 0) }},
+		{"RemoveLast empty", func() { Vector[int]{}.RemoveLast() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("did not panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
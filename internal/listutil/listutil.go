@@ -0,0 +1,81 @@
+// Package listutil provides the helpers shared by the list implementations of this module,
+// so that they follow the java.util.List contracts in the same way.
+package listutil
+
+import (
+	"iter"
+	"reflect"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/objects"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// EqualElements reports whether the size elements of seq are equal to the elements of the other list, in the same order.
+func EqualElements[E any](seq iter.Seq[E], size int, other collection.List[E], equals util.Equals[E]) bool {
+	if size != other.Size() {
+		return false
+	}
+	next, stop := iter.Pull(other.All())
+	defer stop()
+	for v := range seq {
+		w, ok := next()
+		if !ok || !equals(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashElements returns the hash code of the elements of seq, combined as java.util.List specifies.
+func HashElements[E any](seq iter.Seq[E]) uint64 {
+	h := uint64(1)
+	for v := range seq {
+		h = 31*h + objects.DeepHashCode(v)
+	}
+	return h
+}
+
+// Hashable reports whether the elements of type E are compared with == by util.DefaultEquals,
+// so they can be looked up in a hash set.
+func Hashable[E any]() bool {
+	t := reflect.TypeFor[E]()
+	return t.Comparable() && t.Kind() != reflect.Interface
+}
+
+// Membership returns a function reporting whether an element is equal to any of the items.
+// Large batches of hashable elements are put in a hash set, and other elements are compared with equals one by one.
+func Membership[E any](items []E, equals util.Equals[E], hashable bool) func(E) bool {
+	if hashable && len(items) > 8 {
+		set := make(map[any]struct{}, len(items))
+		for _, v := range items {
+			set[any(v)] = struct{}{}
+		}
+		return func(item E) bool {
+			_, ok := set[any(item)]
+			return ok
+		}
+	}
+	return func(item E) bool {
+		for _, v := range items {
+			if equals(item, v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// CheckModCount panics if a list has been structurally modified since an iterator or a sublist expected modCount.
+func CheckModCount(modCount, expected int) {
+	if modCount != expected {
+		panic("Concurrent modification")
+	}
+}
+
+// CheckIndex panics if index is not in the range [0, size).
+func CheckIndex(index, size int) {
+	if err := util.CheckIndex(index, size); err != nil {
+		panic(err.Error())
+	}
+}
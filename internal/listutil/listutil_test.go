@@ -0,0 +1,79 @@
+package listutil_test
+
+import (
+	"testing"
+
+	"github.com/nsce9806q/javastyle-collection/arraylist"
+	"github.com/nsce9806q/javastyle-collection/gaplist"
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+	"github.com/nsce9806q/javastyle-collection/linkedlist"
+	"github.com/nsce9806q/javastyle-collection/unrolledlist"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+func TestListsAgreeOnEqualsAndHashCode(t *testing.T) {
+	items := []string{"a", "b", "c"}
+	a := arraylist.NewFromSlice(items)
+	l := linkedlist.NewFromSlice(items)
+	g := gaplist.NewFromSlice(items)
+	u := unrolledlist.NewFromSlice(items)
+	if !a.Equals(l) || !l.Equals(g) || !g.Equals(u) || !u.Equals(a) {
+		t.Error("lists with the same elements are not equal")
+	}
+	h := a.HashCode()
+	if l.HashCode() != h || g.HashCode() != h || u.HashCode() != h {
+		t.Error("lists with the same elements have different hash codes")
+	}
+
+	u.Set(1, "x")
+	if a.Equals(u) || u.Equals(a) {
+		t.Error("lists with different elements are equal")
+	}
+}
+
+func TestMembership(t *testing.T) {
+	equals := util.DefaultEquals[int]()
+	for _, n := range []int{3, 20} {
+		items := make([]int, n)
+		for i := range items {
+			items[i] = 2 * i
+		}
+		for _, hashable := range []bool{false, true} {
+			contains := listutil.Membership(items, equals, hashable)
+			if !contains(4) || contains(5) {
+				t.Errorf("Membership of %d items, hashable %t: contains(4) = %t, contains(5) = %t", n, hashable, contains(4), contains(5))
+			}
+		}
+	}
+}
+
+func TestHashable(t *testing.T) {
+	if !listutil.Hashable[string]() {
+		t.Error("Hashable[string]() = false, want true")
+	}
+	if listutil.Hashable[any]() || listutil.Hashable[[]int]() {
+		t.Error("Hashable() = true for an interface or slice type, want false")
+	}
+}
+
+func TestCheckIndex(t *testing.T) {
+	listutil.CheckIndex(0, 1)
+	defer func() {
+		if recover() == nil {
+			t.Error("CheckIndex(1, 1) did not panic")
+		}
+	}()
+	listutil.CheckIndex(1, 1)
+}
+
+func TestReversedEqualsAndHashCode(t *testing.T) {
+	a := arraylist.NewFromSlice([]int{1, 2, 3})
+	r := a.Reversed()
+	b := linkedlist.NewFromSlice([]int{3, 2, 1})
+	if !r.Equals(b) || !b.Equals(r) {
+		t.Error("the reversed view is not equal to a list of its elements")
+	}
+	if r.HashCode() != b.HashCode() {
+		t.Error("the reversed view and a list of its elements have different hash codes")
+	}
+}
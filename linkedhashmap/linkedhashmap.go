@@ -0,0 +1,361 @@
+package linkedhashmap
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// LinkedHashMap is a hash table and doubly-linked list implementation of a map, with a predictable iteration order.
+// It mirrors java.util.LinkedHashMap: the mappings are iterated in the order in which their keys were first inserted,
+// which Go maps cannot provide. Re-inserting a key that is already mapped does not change its position.
+// With WithAccessOrder, the mappings are iterated from least recently to most recently accessed instead,
+// and with WithRemoveEldestEntry, the map can evict its eldest mapping as new ones are added, to build an LRU cache.
+// Put, Get, Remove and ContainsKey run in constant time on average, and iteration takes time proportional to the size.
+// The zero value is not usable; create a LinkedHashMap with New.
+type LinkedHashMap[K comparable, V any] struct {
+	entries map[K]*entry[K, V]
+
+	// head and tail are the first and last entries in iteration order.
+	head *entry[K, V]
+	tail *entry[K, V]
+
+	// valueEquals compares values in ContainsValue.
+	valueEquals util.Equals[V]
+
+	// accessOrder moves a mapping to the end of the iteration order whenever it is accessed.
+	accessOrder bool
+
+	// removeEldest reports whether the eldest mapping should be removed after a mapping is added, or is nil.
+	removeEldest func(m *LinkedHashMap[K, V], eldest util.Entry[K, V]) bool
+}
+
+// entry is a mapping of a LinkedHashMap, linked to its neighbours in iteration order.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *entry[K, V]
+	next  *entry[K, V]
+}
+
+// LinkedHashMap implements the Map interface.
+var _ collection.Map[string, int] = (*LinkedHashMap[string, int])(nil)
+
+// Option is a function type that sets the LinkedHashMap.
+type Option[K comparable, V any] func(*LinkedHashMap[K, V])
+
+// WithCapacity is an option that sets the initial capacity, the number of mappings the map can hold without growing.
+func WithCapacity[K comparable, V any](initialCapacity int) Option[K, V] {
+	if initialCapacity < 0 {
+		panic("Illegal capacity")
+	}
+	return func(m *LinkedHashMap[K, V]) {
+		m.entries = make(map[K]*entry[K, V], initialCapacity)
+	}
+}
+
+// WithValueEquals is an option that sets the custom equality comparison function used by ContainsValue.
+// Without it, values are compared by util.DefaultEquals, which uses == when possible and reflect.DeepEqual otherwise.
+func WithValueEquals[K comparable, V any](equals util.Equals[V]) Option[K, V] {
+	return func(m *LinkedHashMap[K, V]) {
+		m.valueEquals = equals
+	}
+}
+
+// WithAccessOrder is an option that orders the mappings by access rather than by insertion: a mapping moves to the end
+// of the iteration order whenever Get, GetOK, GetOrDefault, Put or PutIfAbsent finds its key, so the map is iterated
+// from the least recently accessed mapping to the most recently accessed one. In this mode these methods modify the
+// iteration order. While the map is iterated, the mapping being visited may be accessed, which moves it past the end
+// of the iteration, but accessing any other mapping may make the iteration skip or repeat mappings.
+func WithAccessOrder[K comparable, V any]() Option[K, V] {
+	return func(m *LinkedHashMap[K, V]) {
+		m.accessOrder = true
+	}
+}
+
+// WithRemoveEldestEntry is an option that sets the function called by Put and PutIfAbsent after they add a new mapping.
+// It is given the map and its eldest mapping, the first in iteration order, which is the least recently inserted one,
+// or the least recently accessed one in access order. If it returns true, the eldest mapping is removed.
+// The function may also modify the map itself, in which case it should return false.
+// A bounded LRU cache holding at most 100 mappings is built as follows:
+//
+//	cache := linkedhashmap.New(
+//		linkedhashmap.WithAccessOrder[string, int](),
+//		linkedhashmap.WithRemoveEldestEntry(func(m *linkedhashmap.LinkedHashMap[string, int], _ util.Entry[string, int]) bool {
+//			return m.Size() > 100
+//		}),
+//	)
+//
+// protected boolean removeEldestEntry(Map.Entry<K,V> eldest)
+func WithRemoveEldestEntry[K comparable, V any](removeEldest func(m *LinkedHashMap[K, V], eldest util.Entry[K, V]) bool) Option[K, V] {
+	return func(m *LinkedHashMap[K, V]) {
+		m.removeEldest = removeEldest
+	}
+}
+
+// New creates a new empty LinkedHashMap with the given options.
+func New[K comparable, V any](opts ...Option[K, V]) *LinkedHashMap[K, V] {
+	m := &LinkedHashMap[K, V]{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.entries == nil {
+		m.entries = make(map[K]*entry[K, V])
+	}
+	if m.valueEquals == nil {
+		m.valueEquals = util.DefaultEquals[V]()
+	}
+	return m
+}
+
+// Removes all of the mappings from this map.
+// void clear()
+func (m *LinkedHashMap[K, V]) Clear() {
+	for e := m.head; e != nil; {
+		next := e.next
+		*e = entry[K, V]{}
+		e = next
+	}
+	clear(m.entries)
+	m.head, m.tail = nil, nil
+}
+
+// Returns true if this map contains a mapping for the specified key.
+// boolean containsKey(Object key)
+func (m *LinkedHashMap[K, V]) ContainsKey(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Returns true if this map maps one or more keys to the specified value, in O(n) time.
+// The values are compared with the value equality function of this map.
+// boolean containsValue(Object value)
+func (m *LinkedHashMap[K, V]) ContainsValue(value V) bool {
+	for e := m.head; e != nil; e = e.next {
+		if m.valueEquals(e.value, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Performs the given action for each mapping in this map, in iteration order.
+// void forEach(BiConsumer<? super K,? super V> action)
+func (m *LinkedHashMap[K, V]) ForEach(action util.BiConsumer[K, V]) {
+	for k, v := range m.All() {
+		action(k, v)
+	}
+}
+
+// Returns the value to which the specified key is mapped, or the zero value if this map contains no mapping for the key.
+// V get(Object key)
+func (m *LinkedHashMap[K, V]) Get(key K) V {
+	v, _ := m.GetOK(key)
+	return v
+}
+
+// GetOK returns the value to which the specified key is mapped, and whether this map contains a mapping for the key.
+func (m *LinkedHashMap[K, V]) GetOK(key K) (V, bool) {
+	e, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	m.afterAccess(e)
+	return e.value, true
+}
+
+// Returns the value to which the specified key is mapped, or defaultValue if this map contains no mapping for the key.
+// V getOrDefault(Object key, V defaultValue)
+func (m *LinkedHashMap[K, V]) GetOrDefault(key K, defaultValue V) V {
+	if v, ok := m.GetOK(key); ok {
+		return v
+	}
+	return defaultValue
+}
+
+// Returns true if this map contains no mappings.
+// boolean isEmpty()
+func (m *LinkedHashMap[K, V]) IsEmpty() bool {
+	return len(m.entries) == 0
+}
+
+// All returns an iterator over the mappings in this map, in iteration order, for use with range-over-func.
+// The iteration ends with the mapping that was last when it started, so mappings added during the iteration are not visited.
+// The mapping being visited may be removed, or accessed in access order, during the iteration.
+func (m *LinkedHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e, last := m.head, m.tail; e != nil; {
+			next := e.next
+			if !yield(e.key, e.value) || e == last {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Backward returns an iterator over the mappings in this map, in reverse iteration order, for use with range-over-func.
+// The iteration ends with the mapping that was first when it started.
+// The mapping being visited may be removed, or accessed in access order, during the iteration.
+func (m *LinkedHashMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e, first := m.tail, m.head; e != nil; {
+			prev := e.prev
+			if !yield(e.key, e.value) || e == first {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
+// Keys returns an iterator over the keys in this map, in iteration order, for use with range-over-func.
+// Set<K> keySet()
+func (m *LinkedHashMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values in this map, in iteration order, for use with range-over-func.
+// Collection<V> values()
+func (m *LinkedHashMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Associates the specified value with the specified key in this map, replacing any previous value.
+// A new key is appended to the end of the iteration order, and a key already mapped keeps its position unless
+// the map is in access order.
+// Returns the previous value associated with the key, or the zero value if there was no mapping for the key.
+// V put(K key, V value)
+func (m *LinkedHashMap[K, V]) Put(key K, value V) V {
+	if e, ok := m.entries[key]; ok {
+		old := e.value
+		e.value = value
+		m.afterAccess(e)
+		return old
+	}
+	m.linkLast(key, value)
+	m.afterInsertion()
+	var zero V
+	return zero
+}
+
+// Associates the specified value with the specified key if the key is not already mapped.
+// Returns the current value associated with the key, or the zero value if there was no mapping for the key.
+// V putIfAbsent(K key, V value)
+func (m *LinkedHashMap[K, V]) PutIfAbsent(key K, value V) V {
+	if e, ok := m.entries[key]; ok {
+		m.afterAccess(e)
+		return e.value
+	}
+	m.linkLast(key, value)
+	m.afterInsertion()
+	var zero V
+	return zero
+}
+
+// Removes the mapping for a key from this map if it is present.
+// Returns the value previously associated with the key, or the zero value if there was no mapping for the key.
+// V remove(Object key)
+func (m *LinkedHashMap[K, V]) Remove(key K) V {
+	e, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero
+	}
+	old := e.value
+	m.unlink(e)
+	return old
+}
+
+// Returns the number of mappings in this map.
+// int size()
+func (m *LinkedHashMap[K, V]) Size() int {
+	return len(m.entries)
+}
+
+// Returns a string representation of this map, in the form "{k1=v1, k2=v2}", in iteration order.
+// String toString()
+func (m *LinkedHashMap[K, V]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for e := m.head; e != nil; e = e.next {
+		if e != m.head {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%v=%v", e.key, e.value)
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// linkLast adds a mapping for the key, which is not mapped, at the end of the iteration order.
+func (m *LinkedHashMap[K, V]) linkLast(key K, value V) {
+	e := &entry[K, V]{key: key, value: value, prev: m.tail}
+	if m.tail == nil {
+		m.head = e
+	} else {
+		m.tail.next = e
+	}
+	m.tail = e
+	m.entries[key] = e
+}
+
+// afterAccess moves the accessed entry to the end of the iteration order if this map is in access order.
+// void afterNodeAccess(Node<K,V> e)
+func (m *LinkedHashMap[K, V]) afterAccess(e *entry[K, V]) {
+	if !m.accessOrder || e == m.tail {
+		return
+	}
+	if e.prev == nil {
+		m.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	e.next.prev = e.prev
+	e.prev, e.next = m.tail, nil
+	m.tail.next = e
+	m.tail = e
+}
+
+// afterInsertion removes the eldest entry after a mapping was added, if the removeEldest function asks for it.
+// void afterNodeInsertion(boolean evict)
+func (m *LinkedHashMap[K, V]) afterInsertion() {
+	if m.removeEldest == nil || m.head == nil {
+		return
+	}
+	if m.removeEldest(m, util.NewEntry(m.head.key, m.head.value)) && m.head != nil {
+		m.unlink(m.head)
+	}
+}
+
+// unlink removes the entry from the map and from the iteration order.
+func (m *LinkedHashMap[K, V]) unlink(e *entry[K, V]) {
+	if e.prev == nil {
+		m.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		m.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	delete(m.entries, e.key)
+	*e = entry[K, V]{}
+}
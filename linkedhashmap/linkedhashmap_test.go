@@ -0,0 +1,97 @@
+package linkedhashmap
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+func TestInsertionOrder(t *testing.T) {
+	m := New[string, int]()
+	m.Put("b", 1)
+	m.Put("a", 2)
+	m.Put("c", 3)
+	m.Put("b", 4)
+	m.Get("a")
+
+	if got := m.String(); got != "{b=4, a=2, c=3}" {
+		t.Errorf("String() = %s, want {b=4, a=2, c=3}", got)
+	}
+	if got := slices.Collect(m.Keys()); !slices.Equal(got, []string{"b", "a", "c"}) {
+		t.Errorf("Keys() = %v, want [b a c]", got)
+	}
+}
+
+func TestAccessOrderAccessDuringIteration(t *testing.T) {
+	m := New(WithAccessOrder[string, int]())
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	var visited []string
+	for k := range m.Keys() {
+		visited = append(visited, k)
+		m.Get(k)
+		if len(visited) > 3 {
+			t.Fatalf("visited %v, want each mapping once", visited)
+		}
+	}
+	if !slices.Equal(visited, []string{"a", "b", "c"}) {
+		t.Errorf("visited %v, want [a b c]", visited)
+	}
+
+	visited = nil
+	for k := range m.Backward() {
+		visited = append(visited, k)
+		m.Get(k)
+		if len(visited) > 3 {
+			t.Fatalf("visited %v backward, want each mapping once", visited)
+		}
+	}
+	if !slices.Equal(visited, []string{"c", "b", "a"}) {
+		t.Errorf("visited %v backward, want [c b a]", visited)
+	}
+}
+
+func TestRemoveDuringIteration(t *testing.T) {
+	m := New[int, int]()
+	for i := range 5 {
+		m.Put(i, i)
+	}
+	for k := range m.Keys() {
+		if k%2 == 0 {
+			m.Remove(k)
+		}
+	}
+	if got := m.String(); got != "{1=1, 3=3}" {
+		t.Errorf("String() = %s, want {1=1, 3=3}", got)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := New(
+		WithAccessOrder[string, int](),
+		WithRemoveEldestEntry(func(m *LinkedHashMap[string, int], _ util.Entry[string, int]) bool {
+			return m.Size() > 3
+		}),
+	)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a")
+	cache.Put("d", 4)
+
+	if cache.ContainsKey("b") {
+		t.Error("the least recently used mapping b was not evicted")
+	}
+	if got := cache.String(); got != "{c=3, a=1, d=4}" {
+		t.Errorf("String() = %s, want {c=3, a=1, d=4}", got)
+	}
+
+	cache.PutIfAbsent("c", 0)
+	cache.Put("e", 5)
+	if got := cache.String(); got != "{d=4, c=3, e=5}" {
+		t.Errorf("String() = %s, want {d=4, c=3, e=5}", got)
+	}
+}
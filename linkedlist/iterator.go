@@ -0,0 +1,171 @@
+package linkedlist
+
+import (
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+)
+
+// ListIterator is an iterator over the elements of a LinkedList or a SubList that allows traversal in either direction
+// and modification of the list during iteration. Its cursor always lies between two elements,
+// and every operation takes constant time. If the list is structurally modified other than through the iterator,
+// the iterator fails fast: its methods panic with "Concurrent modification".
+type ListIterator[E any] struct {
+	list *LinkedList[E]
+
+	// sub is the sublist being traversed, or nil if the whole list is traversed.
+	sub *SubList[E]
+
+	// next is the node of the element to be returned by the next call to Next, or nil at the end of the list.
+	next      *node[E]
+	nextIndex int
+
+	// lastReturned is the node returned by the most recent call to Next or Previous,
+	// or nil if there is no such node or it has been removed or followed by a call to Add.
+	lastReturned *node[E]
+
+	// expectedModCount is the modCount of the list that this iterator expects.
+	expectedModCount int
+}
+
+// Returns a list iterator over the elements in this list, starting at the beginning of the list.
+// ListIterator<E> listIterator()
+func (list *LinkedList[E]) ListIterator() *ListIterator[E] {
+	return list.ListIteratorAt(0)
+}
+
+// Returns a list iterator over the elements in this list, starting at the specified position in the list.
+// ListIterator<E> listIterator(int index)
+func (list *LinkedList[E]) ListIteratorAt(index int) *ListIterator[E] {
+	listutil.CheckIndex(index, list.size+1)
+	return &ListIterator[E]{list: list, next: list.nodeOrNil(index), nextIndex: index, expectedModCount: list.modCount}
+}
+
+// Returns a list iterator over the elements in this sublist, starting at the beginning of the sublist.
+// ListIterator<E> listIterator()
+func (sub *SubList[E]) ListIterator() *ListIterator[E] {
+	return sub.ListIteratorAt(0)
+}
+
+// Returns a list iterator over the elements in this sublist, starting at the specified position in the sublist.
+// ListIterator<E> listIterator(int index)
+func (sub *SubList[E]) ListIteratorAt(index int) *ListIterator[E] {
+	sub.checkForComodification()
+	listutil.CheckIndex(index, sub.size+1)
+	return &ListIterator[E]{list: sub.root, sub: sub, next: sub.root.nodeOrNil(sub.offset + index), nextIndex: index, expectedModCount: sub.modCount}
+}
+
+// Returns true if this list iterator has more elements when traversing the list in the forward direction.
+// boolean hasNext()
+func (it *ListIterator[E]) HasNext() bool {
+	return it.nextIndex < it.size()
+}
+
+// Returns the next element in the list and advances the cursor position.
+// E next()
+func (it *ListIterator[E]) Next() E {
+	it.checkForComodification()
+	if !it.HasNext() {
+		panic("No such element")
+	}
+	it.lastReturned = it.next
+	it.next = it.next.next
+	it.nextIndex++
+	return it.lastReturned.item
+}
+
+// Returns true if this list iterator has more elements when traversing the list in the reverse direction.
+// boolean hasPrevious()
+func (it *ListIterator[E]) HasPrevious() bool {
+	return it.nextIndex > 0
+}
+
+// Returns the previous element in the list and moves the cursor position backwards.
+// E previous()
+func (it *ListIterator[E]) Previous() E {
+	it.checkForComodification()
+	if !it.HasPrevious() {
+		panic("No such element")
+	}
+	if it.next == nil {
+		it.next = it.list.last
+	} else {
+		it.next = it.next.prev
+	}
+	it.lastReturned = it.next
+	it.nextIndex--
+	return it.lastReturned.item
+}
+
+// Returns the index of the element that would be returned by a subsequent call to Next, or the list size if at the end of the list.
+// int nextIndex()
+func (it *ListIterator[E]) NextIndex() int {
+	return it.nextIndex
+}
+
+// Returns the index of the element that would be returned by a subsequent call to Previous, or -1 if at the beginning of the list.
+// int previousIndex()
+func (it *ListIterator[E]) PreviousIndex() int {
+	return it.nextIndex - 1
+}
+
+// Removes from the list the last element that was returned by Next or Previous.
+// void remove()
+func (it *ListIterator[E]) Remove() {
+	if it.lastReturned == nil {
+		panic("Illegal state")
+	}
+	it.checkForComodification()
+	lastNext := it.lastReturned.next
+	if it.next == it.lastReturned {
+		// the element was returned by Previous
+		it.next = lastNext
+	} else {
+		it.nextIndex--
+	}
+	it.list.unlink(it.lastReturned)
+	it.lastReturned = nil
+	it.expectedModCount = it.list.modCount
+	if it.sub != nil {
+		it.sub.updateSize(-1)
+	}
+}
+
+// Replaces the last element returned by Next or Previous with the specified element.
+// void set(E e)
+func (it *ListIterator[E]) Set(item E) {
+	if it.lastReturned == nil {
+		panic("Illegal state")
+	}
+	it.checkForComodification()
+	it.lastReturned.item = item
+}
+
+// Inserts the specified element into the list immediately before the element that would be returned by Next.
+// A subsequent call to Previous returns the new element.
+// void add(E e)
+func (it *ListIterator[E]) Add(item E) {
+	it.checkForComodification()
+	it.lastReturned = nil
+	if it.next == nil {
+		it.list.linkLast(item)
+	} else {
+		it.list.linkBefore(item, it.next)
+	}
+	it.nextIndex++
+	it.expectedModCount = it.list.modCount
+	if it.sub != nil {
+		it.sub.updateSize(1)
+	}
+}
+
+// size returns the number of elements being traversed.
+func (it *ListIterator[E]) size() int {
+	if it.sub != nil {
+		return it.sub.size
+	}
+	return it.list.size
+}
+
+// checkForComodification panics if the list has been structurally modified other than through this iterator.
+func (it *ListIterator[E]) checkForComodification() {
+	listutil.CheckModCount(it.list.modCount, it.expectedModCount)
+}
@@ -0,0 +1,614 @@
+package linkedlist
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/collections"
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// LinkedList is a doubly-linked list implementation of a list and a deque.
+// It mirrors java.util.LinkedList: elements are added and removed at either end in constant time,
+// and positional operations traverse the list from the beginning or the end, whichever is closer to the index.
+type LinkedList[E any] struct {
+	first  *node[E]
+	last   *node[E]
+	size   int
+	equals util.Equals[E]
+
+	// hashable is set when the elements are compared with == by default, so they can be looked up in a hash set.
+	hashable bool
+
+	// modCount is the number of structural modifications, which change the size of this list.
+	// Iterators and sublists compare it with the value they expect, to fail fast instead of traversing a list modified under them.
+	modCount int
+}
+
+// node is a node of a LinkedList.
+type node[E any] struct {
+	item E
+	prev *node[E]
+	next *node[E]
+}
+
+// LinkedList implements the List interface.
+var _ collection.List[int] = (*LinkedList[int])(nil)
+
+// Option is a function type that sets the LinkedList.
+type Option[E any] func(*LinkedList[E])
+
+// WithEquals is an option that sets the custom equality comparison function used by Contains, IndexOf and Remove.
+// Without it, elements are compared by util.DefaultEquals, which uses == when possible and reflect.DeepEqual otherwise.
+func WithEquals[E any](equals util.Equals[E]) Option[E] {
+	return func(list *LinkedList[E]) {
+		list.equals = equals
+	}
+}
+
+// New creates a new empty LinkedList with the given options.
+func New[E any](opts ...Option[E]) *LinkedList[E] {
+	list := &LinkedList[E]{}
+	for _, opt := range opts {
+		opt(list)
+	}
+	if list.equals == nil {
+		list.equals = util.DefaultEquals[E]()
+		list.hashable = listutil.Hashable[E]()
+	}
+	return list
+}
+
+// NewFromSlice creates a new LinkedList containing the elements in the given slice, in order.
+func NewFromSlice[E any](items []E, opts ...Option[E]) *LinkedList[E] {
+	list := New(opts...)
+	list.AddAll(items)
+	return list
+}
+
+// Appends the specified element to the end of this list.
+// boolean add(E e)
+func (list *LinkedList[E]) Add(item E) bool {
+	list.linkLast(item)
+	return true
+}
+
+// Inserts the specified element at the specified position in this list,
+// shifting the element currently at that position and any subsequent elements to the right.
+// void add(int index, E element)
+func (list *LinkedList[E]) AddAt(index int, item E) {
+	listutil.CheckIndex(index, list.size+1)
+	if index == list.size {
+		list.linkLast(item)
+	} else {
+		list.linkBefore(item, list.node(index))
+	}
+}
+
+// Appends all of the elements in the specified slice to the end of this list, in order.
+// boolean addAll(Collection<? extends E> c)
+func (list *LinkedList[E]) AddAll(items []E) bool {
+	for _, item := range items {
+		list.linkLast(item)
+	}
+	return len(items) > 0
+}
+
+// Inserts all of the elements in the specified slice into this list at the specified position, in order.
+// The position is located once, and the elements are linked one after another.
+// boolean addAll(int index, Collection<? extends E> c)
+func (list *LinkedList[E]) AddAllAt(index int, items []E) bool {
+	listutil.CheckIndex(index, list.size+1)
+	list.linkAllBefore(items, list.nodeOrNil(index))
+	return len(items) > 0
+}
+
+// Inserts the specified element at the beginning of this list.
+// void addFirst(E e)
+func (list *LinkedList[E]) AddFirst(item E) {
+	list.linkFirst(item)
+}
+
+// Appends the specified element to the end of this list.
+// void addLast(E e)
+func (list *LinkedList[E]) AddLast(item E) {
+	list.linkLast(item)
+}
+
+// Removes all of the elements from this list.
+// void clear()
+func (list *LinkedList[E]) Clear() {
+	for n := list.first; n != nil; {
+		next := n.next
+		*n = node[E]{}
+		n = next
+	}
+	list.first, list.last = nil, nil
+	list.size = 0
+	list.modCount++
+}
+
+// Returns true if this list contains the specified element.
+// boolean contains(Object o)
+func (list *LinkedList[E]) Contains(item E) bool {
+	return list.IndexOf(item) >= 0
+}
+
+// Returns true if this list contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (list *LinkedList[E]) ContainsAll(items []E) bool {
+	for _, item := range items {
+		if !list.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Retrieves, but does not remove, the head (first element) of this list, and panics if this list is empty.
+// E element()
+func (list *LinkedList[E]) Element() E {
+	return list.GetFirst()
+}
+
+// Returns true if the other list contains equal elements in the same order, regardless of its implementation.
+// The elements are compared with the equality function of this list.
+// boolean equals(Object o)
+func (list *LinkedList[E]) Equals(other collection.List[E]) bool {
+	return listutil.EqualElements(list.All(), list.Size(), other, list.equals)
+}
+
+// Performs the given action for each element of this list, in order.
+// void forEach(Consumer<? super E> action)
+func (list *LinkedList[E]) ForEach(action util.Consumer[E]) {
+	expected := list.modCount
+	for n := list.first; n != nil; n = n.next {
+		action(n.item)
+		listutil.CheckModCount(list.modCount, expected)
+	}
+}
+
+// Returns the element at the specified position in this list.
+// E get(int index)
+func (list *LinkedList[E]) Get(index int) E {
+	listutil.CheckIndex(index, list.size)
+	return list.node(index).item
+}
+
+// Returns the first element in this list, and panics if this list is empty.
+// E getFirst()
+func (list *LinkedList[E]) GetFirst() E {
+	if list.first == nil {
+		panic("No such element")
+	}
+	return list.first.item
+}
+
+// Returns the last element in this list, and panics if this list is empty.
+// E getLast()
+func (list *LinkedList[E]) GetLast() E {
+	if list.last == nil {
+		panic("No such element")
+	}
+	return list.last.item
+}
+
+// Returns the hash code of this list, computed from the hash codes of its elements in order as java.util.List specifies.
+// Equal lists have equal hash codes when their elements are compared with the default equality.
+// int hashCode()
+func (list *LinkedList[E]) HashCode() uint64 {
+	return listutil.HashElements(list.All())
+}
+
+// Returns the index of the first occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int indexOf(Object o)
+func (list *LinkedList[E]) IndexOf(item E) int {
+	i := 0
+	for n := list.first; n != nil; n = n.next {
+		if list.equals(n.item, item) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// Returns the index of the last occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int lastIndexOf(Object o)
+func (list *LinkedList[E]) LastIndexOf(item E) int {
+	i := list.size - 1
+	for x := list.last; x != nil; x = x.prev {
+		if list.equals(x.item, item) {
+			return i
+		}
+		i--
+	}
+	return -1
+}
+
+// Returns true if this list contains no elements.
+// boolean isEmpty()
+func (list *LinkedList[E]) IsEmpty() bool {
+	return list.size == 0
+}
+
+// All returns an iterator over the elements in this list, in order, for use with range-over-func.
+// It panics if the list is structurally modified during the iteration.
+func (list *LinkedList[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		expected := list.modCount
+		for n := list.first; n != nil; n = n.next {
+			if !yield(n.item) {
+				return
+			}
+			listutil.CheckModCount(list.modCount, expected)
+		}
+	}
+}
+
+// Backward returns an iterator over the elements in this list, in reverse order, for use with range-over-func.
+// It panics if the list is structurally modified during the iteration.
+// Iterator<E> descendingIterator()
+func (list *LinkedList[E]) Backward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		expected := list.modCount
+		for n := list.last; n != nil; n = n.prev {
+			if !yield(n.item) {
+				return
+			}
+			listutil.CheckModCount(list.modCount, expected)
+		}
+	}
+}
+
+// Adds the specified element as the tail (last element) of this list.
+// boolean offer(E e)
+func (list *LinkedList[E]) Offer(item E) bool {
+	return list.Add(item)
+}
+
+// Inserts the specified element at the front of this list.
+// boolean offerFirst(E e)
+func (list *LinkedList[E]) OfferFirst(item E) bool {
+	list.linkFirst(item)
+	return true
+}
+
+// Inserts the specified element at the end of this list.
+// boolean offerLast(E e)
+func (list *LinkedList[E]) OfferLast(item E) bool {
+	list.linkLast(item)
+	return true
+}
+
+// Retrieves, but does not remove, the head (first element) of this list, or returns zero value if this list is empty.
+// E peek()
+func (list *LinkedList[E]) Peek() E {
+	return list.PeekFirst()
+}
+
+// Retrieves, but does not remove, the first element of this list, or returns zero value if this list is empty.
+// E peekFirst()
+func (list *LinkedList[E]) PeekFirst() E {
+	if list.first == nil {
+		var zero E
+		return zero
+	}
+	return list.first.item
+}
+
+// Retrieves, but does not remove, the last element of this list, or returns zero value if this list is empty.
+// E peekLast()
+func (list *LinkedList[E]) PeekLast() E {
+	if list.last == nil {
+		var zero E
+		return zero
+	}
+	return list.last.item
+}
+
+// Retrieves and removes the head (first element) of this list, or returns zero value if this list is empty.
+// E poll()
+func (list *LinkedList[E]) Poll() E {
+	return list.PollFirst()
+}
+
+// Retrieves and removes the first element of this list, or returns zero value if this list is empty.
+// E pollFirst()
+func (list *LinkedList[E]) PollFirst() E {
+	if list.first == nil {
+		var zero E
+		return zero
+	}
+	return list.unlink(list.first)
+}
+
+// Retrieves and removes the last element of this list, or returns zero value if this list is empty.
+// E pollLast()
+func (list *LinkedList[E]) PollLast() E {
+	if list.last == nil {
+		var zero E
+		return zero
+	}
+	return list.unlink(list.last)
+}
+
+// Pops an element from the stack represented by this list, and panics if this list is empty.
+// It is equivalent to RemoveFirst.
+// E pop()
+func (list *LinkedList[E]) Pop() E {
+	return list.RemoveFirst()
+}
+
+// Pushes an element onto the stack represented by this list.
+// It is equivalent to AddFirst.
+// void push(E e)
+func (list *LinkedList[E]) Push(item E) {
+	list.linkFirst(item)
+}
+
+// Removes the first occurrence of the specified element from this list, if it is present.
+// boolean remove(Object o)
+func (list *LinkedList[E]) Remove(item E) bool {
+	for n := list.first; n != nil; n = n.next {
+		if list.equals(n.item, item) {
+			list.unlink(n)
+			return true
+		}
+	}
+	return false
+}
+
+// Removes all of this list's elements that are also contained in the specified slice, in a single pass.
+// boolean removeAll(Collection<?> c)
+func (list *LinkedList[E]) RemoveAll(items []E) bool {
+	return list.RemoveIf(listutil.Membership(items, list.equals, list.hashable))
+}
+
+// Removes the element at the specified position in this list, shifting any subsequent elements to the left.
+// Returns the element that was removed.
+// E remove(int index)
+func (list *LinkedList[E]) RemoveAt(index int) E {
+	listutil.CheckIndex(index, list.size)
+	return list.unlink(list.node(index))
+}
+
+// Removes all of the elements of this list that satisfy the given predicate, in a single pass.
+// boolean removeIf(Predicate<? super E> filter)
+func (list *LinkedList[E]) RemoveIf(filter util.Predicate[E]) bool {
+	return list.removeIfRange(list.first, list.size, filter) > 0
+}
+
+// Retrieves and removes the first element of this list, and panics if this list is empty.
+// E removeFirst()
+func (list *LinkedList[E]) RemoveFirst() E {
+	if list.first == nil {
+		panic("No such element")
+	}
+	return list.unlink(list.first)
+}
+
+// Retrieves and removes the head (first element) of this list, and panics if this list is empty.
+// E remove()
+func (list *LinkedList[E]) RemoveHead() E {
+	return list.RemoveFirst()
+}
+
+// Retrieves and removes the last element of this list, and panics if this list is empty.
+// E removeLast()
+func (list *LinkedList[E]) RemoveLast() E {
+	if list.last == nil {
+		panic("No such element")
+	}
+	return list.unlink(list.last)
+}
+
+// Retains only the elements in this list that are contained in the specified slice, in a single pass.
+// boolean retainAll(Collection<?> c)
+func (list *LinkedList[E]) RetainAll(items []E) bool {
+	contains := listutil.Membership(items, list.equals, list.hashable)
+	return list.RemoveIf(func(v E) bool {
+		return !contains(v)
+	})
+}
+
+// Replaces each element of this list with the result of applying the operator to that element.
+// void replaceAll(UnaryOperator<E> operator)
+func (list *LinkedList[E]) ReplaceAll(operator util.UnaryOperator[E]) {
+	for x := list.first; x != nil; x = x.next {
+		x.item = operator(x.item)
+	}
+}
+
+// Returns a reverse-ordered view of this list, as collections.Reversed does.
+// Changes made through the view are written through to this list, and changes to this list are visible in the view.
+// List<E> reversed()
+func (list *LinkedList[E]) Reversed() collection.List[E] {
+	return collections.Reversed[E](list)
+}
+
+// Replaces the element at the specified position in this list with the specified element.
+// Returns the element previously at the specified position.
+// E set(int index, E element)
+func (list *LinkedList[E]) Set(index int, item E) E {
+	listutil.CheckIndex(index, list.size)
+	n := list.node(index)
+	old := n.item
+	n.item = item
+	return old
+}
+
+// Returns the number of elements in this list.
+// int size()
+func (list *LinkedList[E]) Size() int {
+	return list.size
+}
+
+// Sorts this list according to the order induced by the specified comparator.
+// The elements are copied into a slice, sorted stably, and written back into the nodes in order.
+// If the comparator is nil, the elements are sorted by util.DefaultComparator, which uses their natural ordering.
+// void sort(Comparator<? super E> c)
+func (list *LinkedList[E]) Sort(comparator util.Comparator[E]) {
+	list.sortRange(list.first, list.size, comparator)
+}
+
+// SortOrdered sorts the list of an ordered type, such as int64, uint or float32, in its natural ordering.
+func SortOrdered[E cmp.Ordered](list *LinkedList[E]) {
+	list.Sort(util.NaturalOrder[E]())
+}
+
+// Returns an array containing all of the elements in this list, in order.
+// Object[] toArray()
+func (list *LinkedList[E]) ToArray() []E {
+	items := make([]E, 0, list.size)
+	for n := list.first; n != nil; n = n.next {
+		items = append(items, n.item)
+	}
+	return items
+}
+
+// Returns a string representation of this list, in the form "[e1, e2, e3]".
+// String toString()
+func (list *LinkedList[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for n := list.first; n != nil; n = n.next {
+		if n != list.first {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, n.item)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// node returns the node at the valid index, traversing from the nearer end of the list.
+func (list *LinkedList[E]) node(index int) *node[E] {
+	if index < list.size/2 {
+		n := list.first
+		for range index {
+			n = n.next
+		}
+		return n
+	}
+	n := list.last
+	for i := list.size - 1; i > index; i-- {
+		n = n.prev
+	}
+	return n
+}
+
+// nodeOrNil returns the node at the index, or nil if the index is the size of the list.
+func (list *LinkedList[E]) nodeOrNil(index int) *node[E] {
+	if index == list.size {
+		return nil
+	}
+	return list.node(index)
+}
+
+// linkFirst links the element as the first element.
+func (list *LinkedList[E]) linkFirst(item E) {
+	n := &node[E]{item: item, next: list.first}
+	if list.first == nil {
+		list.last = n
+	} else {
+		list.first.prev = n
+	}
+	list.first = n
+	list.size++
+	list.modCount++
+}
+
+// linkLast links the element as the last element.
+func (list *LinkedList[E]) linkLast(item E) {
+	n := &node[E]{item: item, prev: list.last}
+	if list.last == nil {
+		list.first = n
+	} else {
+		list.last.next = n
+	}
+	list.last = n
+	list.size++
+	list.modCount++
+}
+
+// linkBefore links the element before the non-nil node succ.
+func (list *LinkedList[E]) linkBefore(item E, succ *node[E]) {
+	n := &node[E]{item: item, prev: succ.prev, next: succ}
+	if succ.prev == nil {
+		list.first = n
+	} else {
+		succ.prev.next = n
+	}
+	succ.prev = n
+	list.size++
+	list.modCount++
+}
+
+// linkAllBefore links the elements in order before the node succ, or at the end of the list if succ is nil.
+func (list *LinkedList[E]) linkAllBefore(items []E, succ *node[E]) {
+	for _, item := range items {
+		if succ == nil {
+			list.linkLast(item)
+		} else {
+			list.linkBefore(item, succ)
+		}
+	}
+}
+
+// unlink unlinks the non-nil node n and returns its element.
+func (list *LinkedList[E]) unlink(n *node[E]) E {
+	item := n.item
+	if n.prev == nil {
+		list.first = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next == nil {
+		list.last = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+	*n = node[E]{}
+	list.size--
+	list.modCount++
+	return item
+}
+
+// removeIfRange unlinks the elements that satisfy the filter among the n elements starting at the node first,
+// and returns the number of removed elements.
+func (list *LinkedList[E]) removeIfRange(first *node[E], n int, filter util.Predicate[E]) int {
+	removed := 0
+	x := first
+	for range n {
+		next := x.next
+		if filter(x.item) {
+			list.unlink(x)
+			removed++
+		}
+		x = next
+	}
+	return removed
+}
+
+// sortRange stably sorts the n elements starting at the node first, by util.DefaultComparator if the comparator is nil.
+func (list *LinkedList[E]) sortRange(first *node[E], n int, comparator util.Comparator[E]) {
+	if comparator == nil {
+		comparator = util.DefaultComparator[E]()
+	}
+	items := make([]E, 0, n)
+	for x := first; len(items) < n; x = x.next {
+		items = append(items, x.item)
+	}
+	slices.SortStableFunc(items, comparator)
+	x := first
+	for _, item := range items {
+		x.item = item
+		x = x.next
+	}
+}
@@ -0,0 +1,217 @@
+package linkedlist
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestListOperations(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 4})
+	list.AddAt(2, 3)
+	list.AddAllAt(0, []int{-1, 0})
+	list.Add(5)
+	if got := list.ToArray(); !slices.Equal(got, []int{-1, 0, 1, 2, 3, 4, 5}) {
+		t.Fatalf("ToArray() = %v, want [-1 0 1 2 3 4 5]", got)
+	}
+	// Get and Set walk from whichever end is closer
+	if list.Get(1) != 0 || list.Get(5) != 4 {
+		t.Errorf("Get(1) = %d, Get(5) = %d, want 0, 4", list.Get(1), list.Get(5))
+	}
+	if old := list.Set(5, 40); old != 4 || list.Get(5) != 40 {
+		t.Errorf("Set(5, 40) = %d, Get(5) = %d, want 4, 40", old, list.Get(5))
+	}
+	if got := list.RemoveAt(0); got != -1 {
+		t.Errorf("RemoveAt(0) = %d, want -1", got)
+	}
+	if !list.Remove(40) || list.Remove(40) {
+		t.Error("Remove(40) did not remove exactly one element")
+	}
+	if got := slices.Collect(list.Backward()); !slices.Equal(got, []int{5, 3, 2, 1, 0}) {
+		t.Errorf("Backward() = %v, want [5 3 2 1 0]", got)
+	}
+}
+
+func TestDequeOperations(t *testing.T) {
+	list := New[int]()
+	list.Push(2)
+	list.AddFirst(1)
+	list.OfferLast(3)
+	list.AddLast(4)
+	if list.PeekFirst() != 1 || list.PeekLast() != 4 || list.Element() != 1 {
+		t.Errorf("PeekFirst() = %d, PeekLast() = %d, Element() = %d", list.PeekFirst(), list.PeekLast(), list.Element())
+	}
+	if got := list.Pop(); got != 1 {
+		t.Errorf("Pop() = %d, want 1", got)
+	}
+	if got := list.PollLast(); got != 4 {
+		t.Errorf("PollLast() = %d, want 4", got)
+	}
+	if got := list.Poll(); got != 2 {
+		t.Errorf("Poll() = %d, want 2", got)
+	}
+	if got := list.RemoveLast(); got != 3 {
+		t.Errorf("RemoveLast() = %d, want 3", got)
+	}
+	if !list.IsEmpty() {
+		t.Fatalf("list is %v, want []", list)
+	}
+	if list.Peek() != 0 || list.PollFirst() != 0 {
+		t.Error("Peek and PollFirst on an empty list did not return the zero value")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("GetFirst() did not panic on an empty list")
+		}
+	}()
+	list.GetFirst()
+}
+
+func TestRemoveIfAndSort(t *testing.T) {
+	list := NewFromSlice([]int{5, 2, 8, 1, 9, 4})
+	if !list.RemoveIf(func(v int) bool { return v%2 == 0 }) {
+		t.Error("RemoveIf() = false, want true")
+	}
+	SortOrdered(list)
+	if got := list.ToArray(); !slices.Equal(got, []int{1, 5, 9}) {
+		t.Errorf("ToArray() = %v, want [1 5 9]", got)
+	}
+	if got := list.String(); got != "[1, 5, 9]" {
+		t.Errorf("String() = %s, want [1, 5, 9]", got)
+	}
+	if list.GetFirst() != 1 || list.GetLast() != 9 {
+		t.Errorf("GetFirst() = %d, GetLast() = %d, want 1, 9", list.GetFirst(), list.GetLast())
+	}
+}
+
+func TestSubListWritesThrough(t *testing.T) {
+	list := NewFromSlice([]int{0, 1, 2, 3, 4, 5})
+	sub := list.SubList(1, 5)
+	nested := sub.SubList(1, 3)
+
+	nested.Add(25)
+	if got := sub.ToArray(); !slices.Equal(got, []int{1, 2, 3, 25, 4}) {
+		t.Errorf("sublist = %v after adding through the nested sublist, want [1 2 3 25 4]", got)
+	}
+	sub.RemoveAt(0)
+	sub.Set(0, 20)
+	if got := list.ToArray(); !slices.Equal(got, []int{0, 20, 3, 25, 4, 5}) {
+		t.Errorf("list = %v, want [0 20 3 25 4 5]", got)
+	}
+	if got := sub.ToArray(); !slices.Equal(got, []int{20, 3, 25, 4}) {
+		t.Errorf("sublist = %v, want [20 3 25 4]", got)
+	}
+
+	// removing through sub is a structural modification that nested did not make
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("nested sublist did not fail fast after a removal through its parent")
+			}
+		}()
+		nested.Size()
+	}()
+
+	sub.Clear()
+	if got := list.ToArray(); !slices.Equal(got, []int{0, 5}) || !sub.IsEmpty() {
+		t.Errorf("list = %v after clearing the sublist, want [0 5]", got)
+	}
+}
+
+func TestSubListComodification(t *testing.T) {
+	list := NewFromSlice([]int{0, 1, 2, 3})
+	sub := list.SubList(1, 3)
+	list.Set(1, 10)
+	if got := sub.Get(0); got != 10 {
+		t.Errorf("Get(0) = %d after a non-structural change to the list, want 10", got)
+	}
+
+	list.AddFirst(-1)
+	defer func() {
+		if r := recover(); r != "Concurrent modification" {
+			t.Errorf("recover() = %v, want Concurrent modification", r)
+		}
+	}()
+	sub.Size()
+}
+
+func TestListIteratorBothDirections(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3})
+	it := list.ListIterator()
+	var forward []int
+	for it.HasNext() {
+		forward = append(forward, it.Next())
+	}
+	var backward []int
+	for it.HasPrevious() {
+		backward = append(backward, it.Previous())
+	}
+	if !slices.Equal(forward, []int{1, 2, 3}) || !slices.Equal(backward, []int{3, 2, 1}) {
+		t.Errorf("forward %v, backward %v, want [1 2 3] and [3 2 1]", forward, backward)
+	}
+	if it.NextIndex() != 0 || it.PreviousIndex() != -1 {
+		t.Errorf("NextIndex() = %d, PreviousIndex() = %d at the beginning", it.NextIndex(), it.PreviousIndex())
+	}
+}
+
+func TestListIteratorModification(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3, 4})
+	it := list.ListIteratorAt(4)
+	for it.HasPrevious() {
+		switch v := it.Previous(); {
+		case v == 3:
+			it.Remove()
+		case v == 2:
+			it.Set(20)
+		case v == 1:
+			it.Add(0)
+			// the added element is returned by the next call to Previous
+			if got := it.Previous(); got != 0 {
+				t.Errorf("Previous() = %d after Add(0), want 0", got)
+			}
+		}
+	}
+	if got := list.ToArray(); !slices.Equal(got, []int{0, 1, 20, 4}) {
+		t.Errorf("ToArray() = %v, want [0 1 20 4]", got)
+	}
+
+	// Add forgets the last returned element, so it cannot be removed
+	defer func() {
+		if r := recover(); r != "Illegal state" {
+			t.Errorf("recover() = %v, want Illegal state", r)
+		}
+	}()
+	it.Add(-1)
+	it.Remove()
+}
+
+func TestSubListListIterator(t *testing.T) {
+	list := NewFromSlice([]int{0, 1, 2, 3, 4})
+	sub := list.SubList(1, 4)
+	it := sub.ListIterator()
+	for it.HasNext() {
+		if v := it.Next(); v%2 == 1 {
+			it.Remove()
+		}
+	}
+	it.Add(9)
+	if got := sub.ToArray(); !slices.Equal(got, []int{2, 9}) {
+		t.Errorf("sublist = %v, want [2 9]", got)
+	}
+	if got := list.ToArray(); !slices.Equal(got, []int{0, 2, 9, 4}) {
+		t.Errorf("list = %v, want [0 2 9 4]", got)
+	}
+}
+
+func TestListIteratorComodification(t *testing.T) {
+	list := NewFromSlice([]int{1, 2})
+	it := list.ListIterator()
+	it.Next()
+	list.Add(3)
+	defer func() {
+		if r := recover(); r != "Concurrent modification" {
+			t.Errorf("recover() = %v, want Concurrent modification", r)
+		}
+	}()
+	it.Next()
+}
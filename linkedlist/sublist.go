@@ -0,0 +1,358 @@
+package linkedlist
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// SubList is a view of a portion of a LinkedList.
+// Changes made through the view are written through to the list, and changes to the list within the portion are
+// visible in the view. If the list is structurally modified other than through the view, the view fails fast:
+// its methods panic with "Concurrent modification" instead of working on elements that may no longer be in the portion.
+// Positional operations traverse the backing list, as they do on the list itself.
+type SubList[E any] struct {
+	root *LinkedList[E]
+
+	// parent is the sublist this sublist was created from, or nil if it was created from root.
+	parent *SubList[E]
+
+	offset int
+	size   int
+
+	// modCount is the modCount of root that this sublist expects, as of its creation or its last structural modification.
+	modCount int
+}
+
+// SubList implements the List interface.
+var _ collection.List[int] = (*SubList[int])(nil)
+
+// Returns a view of the portion of this list between fromIndex, inclusive, and toIndex, exclusive.
+// List<E> subList(int fromIndex, int toIndex)
+func (list *LinkedList[E]) SubList(fromIndex, toIndex int) *SubList[E] {
+	if err := util.CheckFromToIndex(fromIndex, toIndex, list.size); err != nil {
+		panic(err.Error())
+	}
+	return &SubList[E]{root: list, offset: fromIndex, size: toIndex - fromIndex, modCount: list.modCount}
+}
+
+// Appends the specified element to the end of this sublist, inserting it into the backing list.
+// boolean add(E e)
+func (sub *SubList[E]) Add(item E) bool {
+	sub.AddAt(sub.size, item)
+	return true
+}
+
+// Inserts the specified element at the specified position in this sublist, inserting it into the backing list.
+// void add(int index, E element)
+func (sub *SubList[E]) AddAt(index int, item E) {
+	sub.checkForComodification()
+	listutil.CheckIndex(index, sub.size+1)
+	sub.root.AddAt(sub.offset+index, item)
+	sub.updateSize(1)
+}
+
+// Appends all of the elements in the specified slice to the end of this sublist, inserting them into the backing list.
+// boolean addAll(Collection<? extends E> c)
+func (sub *SubList[E]) AddAll(items []E) bool {
+	return sub.AddAllAt(sub.size, items)
+}
+
+// Inserts all of the elements in the specified slice into this sublist at the specified position, inserting them into the backing list.
+// boolean addAll(int index, Collection<? extends E> c)
+func (sub *SubList[E]) AddAllAt(index int, items []E) bool {
+	sub.checkForComodification()
+	listutil.CheckIndex(index, sub.size+1)
+	sub.root.linkAllBefore(items, sub.root.nodeOrNil(sub.offset+index))
+	sub.updateSize(len(items))
+	return len(items) > 0
+}
+
+// Removes all of the elements of this sublist from the backing list.
+// void clear()
+func (sub *SubList[E]) Clear() {
+	sub.checkForComodification()
+	if sub.size == 0 {
+		return
+	}
+	n := sub.root.node(sub.offset)
+	for range sub.size {
+		next := n.next
+		sub.root.unlink(n)
+		n = next
+	}
+	sub.updateSize(-sub.size)
+}
+
+// Returns true if this sublist contains the specified element.
+// boolean contains(Object o)
+func (sub *SubList[E]) Contains(item E) bool {
+	return sub.IndexOf(item) >= 0
+}
+
+// Returns true if this sublist contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (sub *SubList[E]) ContainsAll(items []E) bool {
+	for _, item := range items {
+		if !sub.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the other list contains equal elements in the same order, regardless of its implementation.
+// boolean equals(Object o)
+func (sub *SubList[E]) Equals(other collection.List[E]) bool {
+	sub.checkForComodification()
+	return listutil.EqualElements(sub.All(), sub.size, other, sub.root.equals)
+}
+
+// Performs the given action for each element of this sublist, in order.
+// void forEach(Consumer<? super E> action)
+func (sub *SubList[E]) ForEach(action util.Consumer[E]) {
+	for v := range sub.All() {
+		action(v)
+	}
+}
+
+// Returns the element at the specified position in this sublist.
+// E get(int index)
+func (sub *SubList[E]) Get(index int) E {
+	sub.checkForComodification()
+	listutil.CheckIndex(index, sub.size)
+	return sub.root.node(sub.offset + index).item
+}
+
+// Returns the hash code of this sublist, computed from the hash codes of its elements in order as java.util.List specifies.
+// int hashCode()
+func (sub *SubList[E]) HashCode() uint64 {
+	return listutil.HashElements(sub.All())
+}
+
+// Returns the index of the first occurrence of the specified element in this sublist, or -1 if this sublist does not contain the element.
+// int indexOf(Object o)
+func (sub *SubList[E]) IndexOf(item E) int {
+	i := 0
+	for v := range sub.All() {
+		if sub.root.equals(v, item) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// Returns the index of the last occurrence of the specified element in this sublist, or -1 if this sublist does not contain the element.
+// int lastIndexOf(Object o)
+func (sub *SubList[E]) LastIndexOf(item E) int {
+	sub.checkForComodification()
+	if sub.size == 0 {
+		return -1
+	}
+	x := sub.root.node(sub.offset + sub.size - 1)
+	for i := sub.size - 1; i >= 0; i-- {
+		if sub.root.equals(x.item, item) {
+			return i
+		}
+		x = x.prev
+	}
+	return -1
+}
+
+// Returns true if this sublist contains no elements.
+// boolean isEmpty()
+func (sub *SubList[E]) IsEmpty() bool {
+	sub.checkForComodification()
+	return sub.size == 0
+}
+
+// All returns an iterator over the elements in this sublist, in order, for use with range-over-func.
+// It panics if the backing list is structurally modified during the iteration.
+func (sub *SubList[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		sub.checkForComodification()
+		if sub.size == 0 {
+			return
+		}
+		n := sub.root.node(sub.offset)
+		for range sub.size {
+			if !yield(n.item) {
+				return
+			}
+			sub.checkForComodification()
+			n = n.next
+		}
+	}
+}
+
+// Backward returns an iterator over the elements in this sublist, in reverse order, for use with range-over-func.
+// It panics if the backing list is structurally modified during the iteration.
+func (sub *SubList[E]) Backward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		sub.checkForComodification()
+		if sub.size == 0 {
+			return
+		}
+		n := sub.root.node(sub.offset + sub.size - 1)
+		for range sub.size {
+			if !yield(n.item) {
+				return
+			}
+			sub.checkForComodification()
+			n = n.prev
+		}
+	}
+}
+
+// Removes the first occurrence of the specified element from this sublist and the backing list, if it is present.
+// boolean remove(Object o)
+func (sub *SubList[E]) Remove(item E) bool {
+	sub.checkForComodification()
+	if sub.size == 0 {
+		return false
+	}
+	n := sub.root.node(sub.offset)
+	for range sub.size {
+		if sub.root.equals(n.item, item) {
+			sub.root.unlink(n)
+			sub.updateSize(-1)
+			return true
+		}
+		n = n.next
+	}
+	return false
+}
+
+// Removes all of this sublist's elements that are also contained in the specified slice from the backing list, in a single pass.
+// boolean removeAll(Collection<?> c)
+func (sub *SubList[E]) RemoveAll(items []E) bool {
+	return sub.RemoveIf(listutil.Membership(items, sub.root.equals, sub.root.hashable))
+}
+
+// Removes the element at the specified position in this sublist from the backing list.
+// Returns the element that was removed.
+// E remove(int index)
+func (sub *SubList[E]) RemoveAt(index int) E {
+	sub.checkForComodification()
+	listutil.CheckIndex(index, sub.size)
+	item := sub.root.RemoveAt(sub.offset + index)
+	sub.updateSize(-1)
+	return item
+}
+
+// Removes all of the elements of this sublist that satisfy the given predicate from the backing list, in a single pass.
+// boolean removeIf(Predicate<? super E> filter)
+func (sub *SubList[E]) RemoveIf(filter util.Predicate[E]) bool {
+	sub.checkForComodification()
+	if sub.size == 0 {
+		return false
+	}
+	removed := sub.root.removeIfRange(sub.root.node(sub.offset), sub.size, filter)
+	sub.updateSize(-removed)
+	return removed > 0
+}
+
+// Retains only the elements in this sublist that are contained in the specified slice, removing the others from the backing list.
+// boolean retainAll(Collection<?> c)
+func (sub *SubList[E]) RetainAll(items []E) bool {
+	contains := listutil.Membership(items, sub.root.equals, sub.root.hashable)
+	return sub.RemoveIf(func(v E) bool {
+		return !contains(v)
+	})
+}
+
+// Replaces each element of this sublist with the result of applying the operator to that element, in the backing list.
+// void replaceAll(UnaryOperator<E> operator)
+func (sub *SubList[E]) ReplaceAll(operator util.UnaryOperator[E]) {
+	sub.checkForComodification()
+	if sub.size == 0 {
+		return
+	}
+	x := sub.root.node(sub.offset)
+	for range sub.size {
+		x.item = operator(x.item)
+		x = x.next
+	}
+}
+
+// Replaces the element at the specified position in this sublist with the specified element.
+// Returns the element previously at the specified position.
+// E set(int index, E element)
+func (sub *SubList[E]) Set(index int, item E) E {
+	sub.checkForComodification()
+	listutil.CheckIndex(index, sub.size)
+	return sub.root.Set(sub.offset+index, item)
+}
+
+// Returns the number of elements in this sublist.
+// int size()
+func (sub *SubList[E]) Size() int {
+	sub.checkForComodification()
+	return sub.size
+}
+
+// Sorts this sublist according to the order induced by the specified comparator, rearranging the backing list.
+// The sort is stable. If the comparator is nil, the elements are sorted by util.DefaultComparator.
+// void sort(Comparator<? super E> c)
+func (sub *SubList[E]) Sort(comparator util.Comparator[E]) {
+	sub.checkForComodification()
+	if sub.size > 0 {
+		sub.root.sortRange(sub.root.node(sub.offset), sub.size, comparator)
+	}
+}
+
+// Returns a view of the portion of this sublist between fromIndex, inclusive, and toIndex, exclusive.
+// List<E> subList(int fromIndex, int toIndex)
+func (sub *SubList[E]) SubList(fromIndex, toIndex int) *SubList[E] {
+	sub.checkForComodification()
+	if err := util.CheckFromToIndex(fromIndex, toIndex, sub.size); err != nil {
+		panic(err.Error())
+	}
+	return &SubList[E]{root: sub.root, parent: sub, offset: sub.offset + fromIndex, size: toIndex - fromIndex, modCount: sub.modCount}
+}
+
+// Returns an array containing all of the elements in this sublist, in order.
+// Object[] toArray()
+func (sub *SubList[E]) ToArray() []E {
+	items := make([]E, 0, sub.size)
+	for v := range sub.All() {
+		items = append(items, v)
+	}
+	return items
+}
+
+// Returns a string representation of this sublist, in the form "[e1, e2, e3]".
+// String toString()
+func (sub *SubList[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	i := 0
+	for v := range sub.All() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, v)
+		i++
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// updateSize adds delta to the size of this sublist and of the sublists it was created from,
+// after a structural modification through this sublist, and brings their modCount up to date with it.
+func (sub *SubList[E]) updateSize(delta int) {
+	for s := sub; s != nil; s = s.parent {
+		s.size += delta
+		s.modCount = s.root.modCount
+	}
+}
+
+// checkForComodification panics if the backing list has been structurally modified other than through this sublist.
+func (sub *SubList[E]) checkForComodification() {
+	listutil.CheckModCount(sub.root.modCount, sub.modCount)
+}
@@ -0,0 +1,46 @@
+package lists
+
+import (
+	"github.com/nsce9806q/javastyle-collection/collection"
+)
+
+// subLister is implemented by the lists that provide sublist views, such as arraylist.ArrayList,
+// linkedlist.LinkedList, their sublists and immutable.List.
+type subLister[S any] interface {
+	Size() int
+	SubList(fromIndex, toIndex int) S
+}
+
+// Partition returns consecutive sublist views of the list, each of the given size except the last, which may be smaller.
+// The views are backed by the list as described by its SubList method, so no elements are copied,
+// but the list must not be structurally modified while they are in use. It panics if size is not positive.
+// static <T> List<List<T>> partition(List<T> list, int size)
+func Partition[S any](list subLister[S], size int) []S {
+	if size <= 0 {
+		panic("Partition size must be positive")
+	}
+	n := list.Size()
+	parts := make([]S, 0, n/size+min(n%size, 1))
+	for from := 0; from < n; {
+		to := from + min(size, n-from)
+		parts = append(parts, list.SubList(from, to))
+		from = to
+	}
+	return parts
+}
+
+// Chunk returns the elements of the list split into consecutive slices of the given size, except the last,
+// which may be smaller. The elements are copied, so the chunks are independent of the list. It panics if size is not positive.
+func Chunk[E any](list collection.List[E], size int) [][]E {
+	if size <= 0 {
+		panic("Chunk size must be positive")
+	}
+	items := list.ToArray()
+	chunks := make([][]E, 0, len(items)/size+min(len(items)%size, 1))
+	for from := 0; from < len(items); {
+		to := from + min(size, len(items)-from)
+		chunks = append(chunks, items[from:to:to])
+		from = to
+	}
+	return chunks
+}
@@ -0,0 +1,79 @@
+package lists_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/nsce9806q/javastyle-collection/arraylist"
+	"github.com/nsce9806q/javastyle-collection/immutable"
+	"github.com/nsce9806q/javastyle-collection/lists"
+)
+
+func TestPartition(t *testing.T) {
+	list := immutable.ListOf(1, 2, 3, 4, 5, 6, 7)
+	var got [][]int
+	for _, part := range lists.Partition(list, 3) {
+		got = append(got, part.ToArray())
+	}
+	want := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Partition(3) = %v, want %v", got, want)
+	}
+	if parts := lists.Partition(immutable.ListOf[int](), 3); len(parts) != 0 {
+		t.Errorf("Partition of an empty list = %v, want none", parts)
+	}
+}
+
+func TestPartitionViews(t *testing.T) {
+	list := arraylist.NewFromSlice([]int{1, 2, 3, 4})
+	parts := lists.Partition(list, 2)
+	parts[1].Set(0, 30)
+	if got := list.Get(2); got != 30 {
+		t.Errorf("Get(2) = %d after setting through the partition, want 30", got)
+	}
+}
+
+func TestChunkCopies(t *testing.T) {
+	list := arraylist.NewFromSlice([]int{1, 2, 3, 4, 5})
+	chunks := lists.Chunk(list, 2)
+	want := [][]int{{1, 2}, {3, 4}, {5}}
+	if !slices.EqualFunc(chunks, want, slices.Equal) {
+		t.Fatalf("Chunk(2) = %v, want %v", chunks, want)
+	}
+
+	// appending to a chunk must not overwrite the next one
+	chunks[0] = append(chunks[0], 9)
+	chunks[1][0] = 30
+	if !slices.Equal(chunks[1], []int{30, 4}) || list.Get(2) != 3 {
+		t.Errorf("chunks share storage: %v, list %v", chunks, list)
+	}
+}
+
+func TestNonPositiveSizePanics(t *testing.T) {
+	for _, f := range []func(){
+		func() { lists.Partition(immutable.ListOf(1), 0) },
+		func() { lists.Chunk[int](arraylist.New[int](), -1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("did not panic for a non-positive size")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestSizeLargerThanList(t *testing.T) {
+	list := arraylist.NewFromSlice([]int{1, 2, 3})
+	for _, size := range []int{4, math.MaxInt} {
+		if parts := lists.Partition(list, size); len(parts) != 1 || parts[0].Size() != 3 {
+			t.Errorf("Partition(%d) = %v, want one part of the whole list", size, parts)
+		}
+		if chunks := lists.Chunk[int](list, size); len(chunks) != 1 || !slices.Equal(chunks[0], []int{1, 2, 3}) {
+			t.Errorf("Chunk(%d) = %v, want [[1 2 3]]", size, chunks)
+		}
+	}
+}
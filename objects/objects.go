@@ -0,0 +1,188 @@
+package objects
+
+import (
+	"fmt"
+	"hash/maphash"
+	"reflect"
+)
+
+// Equals reports whether a and b are equal.
+// Values of comparable types are compared with ==, and other values, such as slices, maps
+// and interfaces holding them, are compared with reflect.DeepEqual instead of panicking.
+// static boolean equals(Object a, Object b)
+func Equals[T any](a, b T) bool {
+	ta := reflect.TypeOf(any(a))
+	if ta == nil || reflect.TypeOf(any(b)) != ta {
+		// nil interfaces, or interfaces holding different dynamic types
+		return any(a) == any(b)
+	}
+	if ta.Comparable() && !hasInterface(ta) {
+		return any(a) == any(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// hasInterface reports whether a comparable type contains an interface, whose dynamic value may not be comparable.
+func hasInterface(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Array:
+		return hasInterface(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if hasInterface(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasPointer reports whether a comparable type contains a pointer, which == compares by address but reflect.DeepEqual follows.
+func hasPointer(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return true
+	case reflect.Array:
+		return hasPointer(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if hasPointer(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hashSeed is the seed of HashCode and DeepHashCode, chosen randomly once per process.
+var hashSeed = maphash.MakeSeed()
+
+// HashCode returns the hash code of a comparable value, consistent with ==.
+// Hash codes are randomized per process and must not be persisted.
+// static int hashCode(Object o)
+func HashCode[T comparable](v T) uint64 {
+	// hashed as an interface, like the values DeepHashCode hashes through reflection
+	return maphash.Comparable(hashSeed, any(v))
+}
+
+// DeepHashCode returns a hash code for any value that is consistent with Equals.
+// Values that Equals compares with == are hashed as with HashCode, so a pointer is hashed by its address.
+// Other values, which Equals compares with reflect.DeepEqual, are hashed element by element the way DeepEqual
+// compares them: the pointers they contain are followed, and functions are hashed by whether they are nil.
+// Hash codes are randomized per process and must not be persisted.
+// static int deepHashCode(Object[] a)
+func DeepHashCode[T any](v T) uint64 {
+	rv := reflect.ValueOf(any(v))
+	if rv.IsValid() && rv.Type().Comparable() && !hasInterface(rv.Type()) {
+		return maphash.Comparable(hashSeed, any(v))
+	}
+	return deepHash(rv, make(map[visit]bool))
+}
+
+// visit identifies a pointer, map or slice on the path from the value passed to DeepHashCode, to detect cycles.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// deepHash returns the hash code of a value for DeepHashCode, consistent with reflect.DeepEqual.
+// A pointer, map or slice that refers back to one of its enclosing values is hashed as 0.
+func deepHash(v reflect.Value, visiting map[visit]bool) uint64 {
+	if !v.IsValid() {
+		return 0
+	}
+	t := v.Type()
+	if v.CanInterface() && t.Comparable() && !hasInterface(t) && !hasPointer(t) {
+		return maphash.Comparable(hashSeed, v.Interface())
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return 0
+		}
+		k := visit{v.Pointer(), t}
+		if visiting[k] {
+			return 0
+		}
+		visiting[k] = true
+		defer delete(visiting, k)
+	}
+
+	var h uint64
+	switch v.Kind() {
+	case reflect.Bool:
+		return maphash.Comparable(hashSeed, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return maphash.Comparable(hashSeed, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return maphash.Comparable(hashSeed, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return maphash.Comparable(hashSeed, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return maphash.Comparable(hashSeed, v.Complex())
+	case reflect.String:
+		return maphash.Comparable(hashSeed, v.String())
+	case reflect.Chan, reflect.UnsafePointer:
+		return maphash.Comparable(hashSeed, v.Pointer())
+	case reflect.Pointer, reflect.Interface:
+		return deepHash(v.Elem(), visiting)
+	case reflect.Slice, reflect.Array:
+		h = 1
+		for i := range v.Len() {
+			h = 31*h + deepHash(v.Index(i), visiting)
+		}
+	case reflect.Map:
+		// the order of the entries does not matter
+		for iter := v.MapRange(); iter.Next(); {
+			h += deepHash(iter.Key(), visiting) ^ deepHash(iter.Value(), visiting)
+		}
+	case reflect.Struct:
+		h = 1
+		for i := range v.NumField() {
+			h = 31*h + deepHash(v.Field(i), visiting)
+		}
+	case reflect.Func:
+		if !v.IsNil() {
+			h = 1
+		}
+	}
+	return h
+}
+
+// IsNil reports whether v is nil: a nil interface, or a nil pointer, map, slice, channel or function.
+// static boolean isNull(Object obj)
+func IsNil[T any](v T) bool {
+	rv := reflect.ValueOf(any(v))
+	if !rv.IsValid() {
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// RequireNonNil returns v if it is not nil, and panics otherwise.
+// The panic value is the given message, or "Nil value" if none is given.
+// static <T> T requireNonNull(T obj, String message)
+func RequireNonNil[T any](v T, message ...string) T {
+	if IsNil(v) {
+		if len(message) > 0 {
+			panic(message[0])
+		}
+		panic("Nil value")
+	}
+	return v
+}
+
+// ToStringOrDefault returns the result of formatting v with fmt.Sprint if v is not nil, and nilDefault otherwise.
+// static String toString(Object o, String nullDefault)
+func ToStringOrDefault[T any](v T, nilDefault string) string {
+	if IsNil(v) {
+		return nilDefault
+	}
+	return fmt.Sprint(v)
+}
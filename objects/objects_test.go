@@ -0,0 +1,63 @@
+package objects
+
+import (
+	"testing"
+)
+
+func TestDeepHashCodeConsistentWithEquals(t *testing.T) {
+	a, b := 1, 1
+	type node struct {
+		val  *int
+		tags []string
+	}
+	tests := []struct {
+		name string
+		x, y any
+	}{
+		{"slices of pointers", []*int{&a}, []*int{&b}},
+		{"maps of pointers", map[string]*int{"k": &a}, map[string]*int{"k": &b}},
+		{"structs with pointers", node{&a, []string{"t"}}, node{&b, []string{"t"}}},
+		{"pointers to slices", []*[]int{{1, 2}}, []*[]int{{1, 2}}},
+		{"nested interfaces", []any{&a, "s"}, []any{&b, "s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !Equals(tt.x, tt.y) {
+				t.Fatalf("Equals(%v, %v) = false, want true", tt.x, tt.y)
+			}
+			if DeepHashCode(tt.x) != DeepHashCode(tt.y) {
+				t.Errorf("DeepHashCode differs for equal values %v and %v", tt.x, tt.y)
+			}
+		})
+	}
+}
+
+func TestDeepHashCodeComparablePointers(t *testing.T) {
+	a, b := 1, 1
+	if Equals(&a, &b) {
+		t.Fatal("Equals(&a, &b) = true, want pointers compared by address")
+	}
+	if DeepHashCode(&a) != HashCode(&a) {
+		t.Error("DeepHashCode(&a) != HashCode(&a)")
+	}
+}
+
+func TestDeepHashCodeCycle(t *testing.T) {
+	type list struct {
+		next *list
+		vals []int
+	}
+	x := &list{vals: []int{1}}
+	x.next = x
+	y := &list{vals: []int{1}}
+	y.next = y
+
+	// DeepHashCode must terminate on cyclic values
+	if DeepHashCode([]*list{x}) != DeepHashCode([]*list{y}) {
+		t.Error("DeepHashCode differs for equal cyclic values")
+	}
+
+	s := []any{nil}
+	s[0] = s
+	DeepHashCode(s)
+}
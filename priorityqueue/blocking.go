@@ -0,0 +1,204 @@
+package priorityqueue
+
+import (
+	"context"
+	"iter"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// PriorityBlockingQueue is an unbounded thread-safe priority queue with blocking retrieval operations.
+// It is modeled on java.util.concurrent.PriorityBlockingQueue.
+type PriorityBlockingQueue[E any] struct {
+	mu sync.Mutex
+	pq *PriorityQueue[E]
+
+	// notEmpty is closed and replaced whenever an element is inserted, waking up all waiting takers.
+	notEmpty chan struct{}
+}
+
+// NewBlocking creates a new PriorityBlockingQueue with the given options.
+// The queue is unbounded, so WithMaxCapacity has no effect.
+func NewBlocking[E any](opts ...Option[E]) *PriorityBlockingQueue[E] {
+	pq := New(opts...)
+	pq.maxCapacity = 0
+	return &PriorityBlockingQueue[E]{
+		pq:       pq,
+		notEmpty: make(chan struct{}),
+	}
+}
+
+// signalNotEmpty wakes up all goroutines waiting for an element.
+// It must be called with the lock held.
+func (q *PriorityBlockingQueue[E]) signalNotEmpty() {
+	close(q.notEmpty)
+	q.notEmpty = make(chan struct{})
+}
+
+// Inserts the specified element into this priority queue.
+// boolean add(E e)
+func (q *PriorityBlockingQueue[E]) Add(item E) bool {
+	return q.Offer(item)
+}
+
+// Inserts the specified element into this priority queue.
+// boolean offer(E e)
+func (q *PriorityBlockingQueue[E]) Offer(item E) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.pq.Offer(item) {
+		return false
+	}
+	q.signalNotEmpty()
+	return true
+}
+
+// Inserts the specified element into this priority queue.
+// As the queue is unbounded, this method never blocks.
+// void put(E e)
+func (q *PriorityBlockingQueue[E]) Put(item E) {
+	q.Offer(item)
+}
+
+// Retrieves and removes the head of this queue, waiting if necessary until an element becomes available.
+// Returns the context error if the context is done before an element becomes available.
+// E take()
+func (q *PriorityBlockingQueue[E]) Take(ctx context.Context) (E, error) {
+	for {
+		q.mu.Lock()
+		if q.pq.Size() > 0 {
+			item := q.pq.Poll()
+			q.mu.Unlock()
+			return item, nil
+		}
+		notEmpty := q.notEmpty
+		q.mu.Unlock()
+
+		select {
+		case <-notEmpty:
+		case <-ctx.Done():
+			var zero E
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Retrieves and removes the head of this queue, waiting up to the specified wait time if necessary for an element to become available.
+// Returns false if the wait time elapses before an element becomes available.
+// E poll(long timeout, TimeUnit unit)
+func (q *PriorityBlockingQueue[E]) PollTimeout(timeout time.Duration) (E, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	item, err := q.Take(ctx)
+	if err != nil {
+		return item, false
+	}
+	return item, true
+}
+
+// Retrieves and removes the head of this queue, or returns zero value if this queue is empty.
+// E poll()
+func (q *PriorityBlockingQueue[E]) Poll() E {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Poll()
+}
+
+// Retrieves, but does not remove, the head of this queue, or returns zero value if this queue is empty.
+// E peek()
+func (q *PriorityBlockingQueue[E]) Peek() E {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Peek()
+}
+
+// Removes all of the elements from this priority queue.
+// void clear()
+func (q *PriorityBlockingQueue[E]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pq.Clear()
+}
+
+// Returns the comparator used to order the elements in this queue.
+// Comparator<? super E> comparator()
+func (q *PriorityBlockingQueue[E]) Comparator() util.Comparator[E] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Comparator()
+}
+
+// Returns true if this queue contains the specified element.
+// boolean contains(Object o)
+func (q *PriorityBlockingQueue[E]) Contains(item E) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Contains(item)
+}
+
+// Removes the specified element from this queue if it is present.
+// boolean remove(Object o)
+func (q *PriorityBlockingQueue[E]) Remove(item E) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Remove(item)
+}
+
+// Removes at most the given number of elements from this queue and appends them to dst in priority order.
+// int drainTo(Collection<? super E> c, int maxElements)
+func (q *PriorityBlockingQueue[E]) DrainTo(dst *[]E, maxElements int) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.DrainTo(dst, maxElements)
+}
+
+// Returns a weakly consistent iterator over a snapshot of the elements in this queue.
+// Iterator<E> iterator()
+func (q *PriorityBlockingQueue[E]) Iterator() *SnapshotIterator[E] {
+	return newSnapshotIterator(q.ToArray(), q.Remove)
+}
+
+// All returns an iterator over a snapshot of the elements in this queue, taken when iteration starts.
+func (q *PriorityBlockingQueue[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, v := range q.ToArray() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Always returns math.MaxInt because a PriorityBlockingQueue is not capacity constrained.
+// int remainingCapacity()
+func (q *PriorityBlockingQueue[E]) RemainingCapacity() int {
+	return math.MaxInt
+}
+
+// Returns the number of elements in this queue.
+// int size()
+func (q *PriorityBlockingQueue[E]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Size()
+}
+
+// Returns an array containing all of the elements in this queue.
+// Object[] toArray()
+func (q *PriorityBlockingQueue[E]) ToArray() []E {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.ToArray()
+}
+
+// Returns a string representation of this queue, in the form "[e1, e2, e3]".
+// String toString()
+func (q *PriorityBlockingQueue[E]) String() string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.String()
+}
@@ -0,0 +1,149 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTakeWaitsForOffer(t *testing.T) {
+	q := NewBlocking[int]()
+	got := make(chan int)
+	go func() {
+		v, err := q.Take(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		got <- v
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Put(7)
+	select {
+	case v := <-got:
+		if v != 7 {
+			t.Errorf("Take() = %d, want 7", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Take() did not return after Put")
+	}
+}
+
+func TestTakeCanceled(t *testing.T) {
+	q := NewBlocking[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := q.Take(ctx)
+		errs <- err
+	}()
+
+	cancel()
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Take() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Take() did not return after the context was canceled")
+	}
+
+	// an element offered after the cancellation stays in the queue
+	q.Offer(1)
+	if got := q.Size(); got != 1 {
+		t.Errorf("Size() = %d, want 1", got)
+	}
+}
+
+func TestPollTimeout(t *testing.T) {
+	q := NewBlocking[int]()
+	start := time.Now()
+	if v, ok := q.PollTimeout(20 * time.Millisecond); ok {
+		t.Errorf("PollTimeout() = %d, true on an empty queue", v)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("PollTimeout() returned after %v, want at least 20ms", elapsed)
+	}
+
+	q.Offer(3)
+	q.Offer(1)
+	if v, ok := q.PollTimeout(time.Second); !ok || v != 1 {
+		t.Errorf("PollTimeout() = %d, %t, want 1, true", v, ok)
+	}
+}
+
+// TestProducersConsumers checks, under the race detector, that every element offered by concurrent producers
+// is taken exactly once by concurrent consumers.
+func TestProducersConsumers(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 500
+	q := NewBlocking[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	taken := make(chan int, producers*perProducer)
+	var cwg sync.WaitGroup
+	for range consumers {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			for {
+				v, err := q.Take(ctx)
+				if err != nil {
+					return
+				}
+				taken <- v
+			}
+		}()
+	}
+
+	var pwg sync.WaitGroup
+	for p := range producers {
+		pwg.Add(1)
+		go func() {
+			defer pwg.Done()
+			for i := range perProducer {
+				q.Put(p*perProducer + i)
+			}
+		}()
+	}
+	pwg.Wait()
+
+	var all []int
+	for range producers * perProducer {
+		select {
+		case v := <-taken:
+			all = append(all, v)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("took %d elements, want %d", len(all), producers*perProducer)
+		}
+	}
+	cancel()
+	cwg.Wait()
+
+	slices.Sort(all)
+	for i, v := range all {
+		if v != i {
+			t.Fatalf("element %d missing or duplicated", i)
+		}
+	}
+	if got := q.Size(); got != 0 {
+		t.Errorf("Size() = %d, want 0", got)
+	}
+}
+
+func TestDrainTo(t *testing.T) {
+	q := NewBlocking(WithInitialItems(4, 2, 3, 1))
+	var dst []int
+	if n := q.DrainTo(&dst, 3); n != 3 {
+		t.Errorf("DrainTo() = %d, want 3", n)
+	}
+	if !slices.Equal(dst, []int{1, 2, 3}) {
+		t.Errorf("drained %v, want [1 2 3]", dst)
+	}
+	if got := q.Poll(); got != 4 {
+		t.Errorf("Poll() = %d, want 4", got)
+	}
+}
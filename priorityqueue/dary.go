@@ -0,0 +1,230 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"math/bits"
+	"slices"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// parent returns the index of the parent of the node at index i.
+func (ph *internalHeap[E]) parent(i int) int {
+	return (i - 1) / ph.arity
+}
+
+// firstChild returns the index of the first child of the node at index i.
+func (ph *internalHeap[E]) firstChild(i int) int {
+	return ph.arity*i + 1
+}
+
+// init establishes the heap invariants in O(n) time.
+func (ph *internalHeap[E]) init() {
+	n := ph.Len()
+	for i := ph.parent(n - 1); i >= 0; i-- {
+		ph.down(i, n)
+	}
+	ph.debugCheck()
+}
+
+// push pushes the element x onto the heap.
+func (ph *internalHeap[E]) push(x E) {
+	ph.appendItems(x)
+	ph.up(ph.Len() - 1)
+	ph.debugCheck()
+}
+
+// pushAll pushes all of the given elements onto the heap.
+// Sifting up each new element costs at most about k*log(n+k) comparisons, while rebuilding the heap costs about 2*(n+k),
+// so the strategy with the lower worst case is used depending on the size of the batch relative to the heap.
+// Random elements rarely sift up far, so for batches somewhat smaller than the heap sifting would often be cheaper,
+// but a batch of elements that all sort first would cost up to log(n+k) times more; see BenchmarkOfferAll.
+func (ph *internalHeap[E]) pushAll(items []E) {
+	n, k := ph.Len(), len(items)
+	ph.appendItems(items...)
+	if k*bits.Len(uint(n+k)) < 2*(n+k) {
+		for i := n; i < n+k; i++ {
+			ph.up(i)
+		}
+		ph.debugCheck()
+	} else {
+		ph.init()
+	}
+}
+
+// pop removes and returns the minimum element (according to Less) from the non-empty heap.
+func (ph *internalHeap[E]) pop() E {
+	n := ph.Len() - 1
+	ph.Swap(0, n)
+	ph.down(0, n)
+	item := ph.removeLast()
+	ph.debugCheck()
+	return item
+}
+
+// peek returns the minimum element of the non-empty heap.
+func (ph *internalHeap[E]) peek() E {
+	return ph.items[0]
+}
+
+// all returns an iterator over the elements of the backing slice.
+func (ph *internalHeap[E]) all() iter.Seq[E] {
+	return slices.Values(ph.items)
+}
+
+// removeFunc removes the first element of the backing slice that satisfies match.
+func (ph *internalHeap[E]) removeFunc(match func(E) bool) (E, bool) {
+	i := slices.IndexFunc(ph.items, match)
+	if i < 0 {
+		var zero E
+		return zero, false
+	}
+	return ph.remove(i), true
+}
+
+// reorder replaces the comparator and rebuilds the heap.
+func (ph *internalHeap[E]) reorder(comparator util.Comparator[E]) {
+	ph.comparator = comparator
+	ph.init()
+}
+
+// smallest returns the k smallest elements in priority order, exploring only the top of the heap in O(k log k) time.
+func (ph *internalHeap[E]) smallest(k int) []E {
+	// frontier is a heap of the indexes of the candidates for the next element
+	frontier := &internalHeap[int]{
+		items: make([]int, 0, k*ph.arity),
+		comparator: func(i, j int) int {
+			if ph.Less(i, j) {
+				return -1
+			}
+			if ph.Less(j, i) {
+				return 1
+			}
+			return 0
+		},
+		arity: 2,
+	}
+	frontier.push(0)
+
+	items := make([]E, 0, k)
+	for len(items) < k {
+		i := frontier.pop()
+		items = append(items, ph.items[i])
+		first := ph.firstChild(i)
+		for child := first; child < min(first+ph.arity, ph.Len()); child++ {
+			frontier.push(child)
+		}
+	}
+	return items
+}
+
+// occurrences returns the number of occurrences of the element with the key k.
+func (ph *internalHeap[E]) occurrences(k any) int {
+	return ph.counts[k]
+}
+
+// clone returns a copy of the heap with the same capacity.
+// The moved hook is not copied, since it refers to the queue that owns the heap.
+func (ph *internalHeap[E]) clone() backend[E] {
+	items := make([]E, len(ph.items), cap(ph.items))
+	copy(items, ph.items)
+	return &internalHeap[E]{
+		items:           items,
+		comparator:      ph.comparator,
+		arity:           ph.arity,
+		shrinkThreshold: ph.shrinkThreshold,
+		seqs:            slices.Clone(ph.seqs),
+		nextSeq:         ph.nextSeq,
+		stable:          ph.stable,
+		counts:          maps.Clone(ph.counts),
+	}
+}
+
+// remove removes and returns the element at index i from the heap.
+func (ph *internalHeap[E]) remove(i int) E {
+	n := ph.Len() - 1
+	if n != i {
+		ph.Swap(i, n)
+		if !ph.down(i, n) {
+			ph.up(i)
+		}
+	}
+	item := ph.removeLast()
+	ph.debugCheck()
+	return item
+}
+
+// fix re-establishes the heap ordering after the element at index i has changed its value.
+func (ph *internalHeap[E]) fix(i int) {
+	if !ph.down(i, ph.Len()) {
+		ph.up(i)
+	}
+	ph.debugCheck()
+}
+
+// up moves the element at index j towards the root until its parent sorts before it.
+func (ph *internalHeap[E]) up(j int) {
+	for j > 0 {
+		i := ph.parent(j)
+		if !ph.Less(j, i) {
+			break
+		}
+		ph.Swap(i, j)
+		j = i
+	}
+}
+
+// down moves the element at index i0 towards the leaves, considering only the first n elements,
+// until it sorts before all of its children. It reports whether the element was moved.
+func (ph *internalHeap[E]) down(i0, n int) bool {
+	i := i0
+	for {
+		first := ph.firstChild(i)
+		if first >= n || first < 0 { // first < 0 after int overflow
+			break
+		}
+		best := first
+		for c := first + 1; c < min(first+ph.arity, n); c++ {
+			if ph.Less(c, best) {
+				best = c
+			}
+		}
+		if !ph.Less(best, i) {
+			break
+		}
+		ph.Swap(i, best)
+		i = best
+	}
+	return i > i0
+}
+
+// check verifies that every element sorts no earlier than its parent,
+// and that the sequence numbers and the occurrence counts match the elements.
+func (ph *internalHeap[E]) check() error {
+	if ph.stable && len(ph.seqs) != len(ph.items) {
+		return fmt.Errorf("priorityqueue: %d sequence numbers for %d elements", len(ph.seqs), len(ph.items))
+	}
+	for i := 1; i < ph.Len(); i++ {
+		if p := ph.parent(i); ph.Less(i, p) {
+			return fmt.Errorf("priorityqueue: heap property violated between index %d and its parent %d", i, p)
+		}
+	}
+	return ph.counts.check(ph.all())
+}
+
+// debugCheck panics if the heap invariants do not hold. It does nothing unless built with the pqdebug tag.
+func (ph *internalHeap[E]) debugCheck() {
+	debugCheck(ph)
+}
+
+// debugCheck panics if the invariants of the given heap do not hold. It does nothing unless built with the pqdebug tag.
+func debugCheck(h interface{ check() error }) {
+	if !debugInvariants {
+		return
+	}
+	if err := h.check(); err != nil {
+		panic(err)
+	}
+}
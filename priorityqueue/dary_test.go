@@ -0,0 +1,163 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// randomInts returns n pseudo-random ints, the same for every run.
+func randomInts(n int) []int {
+	r := rand.New(rand.NewPCG(uint64(n), 42))
+	items := make([]int, n)
+	for i := range items {
+		items[i] = r.Int()
+	}
+	return items
+}
+
+// newIntHeap returns a d-ary heap of the given ints whose comparator counts its calls in *compares.
+func newIntHeap(items []int, arity int, compares *int) *internalHeap[int] {
+	h := &internalHeap[int]{
+		items: append(make([]int, 0, len(items)), items...),
+		comparator: func(a, b int) int {
+			*compares++
+			return cmp.Compare(a, b)
+		},
+		arity: arity,
+	}
+	h.init()
+	return h
+}
+
+// siftEach inserts the items by sifting up each one, the strategy pushAll uses for small batches.
+func siftEach(h *internalHeap[int], items []int) {
+	n := h.Len()
+	h.appendItems(items...)
+	for i := n; i < h.Len(); i++ {
+		h.up(i)
+	}
+}
+
+// heapify inserts the items by rebuilding the whole heap, the strategy pushAll uses for large batches.
+func heapify(h *internalHeap[int], items []int) {
+	h.appendItems(items...)
+	h.init()
+}
+
+func TestDAryHeapOrder(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		pq := New(WithArity[int](d), WithInitialItems(randomInts(500)...))
+		pq.OfferAll(randomInts(20))
+		pq.OfferAll(randomInts(2000))
+		if err := pq.CheckInvariants(); err != nil {
+			t.Fatalf("arity %d: %v", d, err)
+		}
+		prev := pq.Poll()
+		for !pq.IsEmpty() {
+			v := pq.Poll()
+			if v < prev {
+				t.Fatalf("arity %d: polled %d after %d", d, v, prev)
+			}
+			prev = v
+		}
+	}
+}
+
+// TestPushAllCutoff checks that pushAll, which picks a strategy by comparing k*bits.Len(n+k) with 2*(n+k),
+// needs no more comparisons than the cheaper of the two strategies, within a margin for batches near the crossover.
+func TestPushAllCutoff(t *testing.T) {
+	if debugInvariants {
+		t.Skip("the pqdebug invariant checks call the comparator")
+	}
+	for _, n := range []int{0, 100, 10000} {
+		for _, k := range []int{1, 10, 100, 1000, 10000, 100000} {
+			heap, batch := randomInts(n), randomInts(k)
+
+			var sifted, rebuilt, chosen int
+			h := newIntHeap(heap, 2, &sifted)
+			sifted = 0
+			siftEach(h, batch)
+			h = newIntHeap(heap, 2, &rebuilt)
+			rebuilt = 0
+			heapify(h, batch)
+			h = newIntHeap(heap, 2, &chosen)
+			chosen = 0
+			h.pushAll(batch)
+
+			best := min(sifted, rebuilt)
+			if float64(chosen) > 1.5*float64(best)+10 {
+				t.Errorf("n=%d k=%d: pushAll made %d comparisons, sift-up %d and heapify %d", n, k, chosen, sifted, rebuilt)
+			}
+		}
+	}
+}
+
+func BenchmarkArity(b *testing.B) {
+	for _, n := range []int{1_000, 100_000} {
+		items := randomInts(n)
+		for _, d := range []int{2, 4, 8} {
+			b.Run(fmt.Sprintf("n=%d/d=%d/offer", n, d), func(b *testing.B) {
+				for b.Loop() {
+					pq := New(WithArity[int](d), WithCapacity[int](n))
+					for _, v := range items {
+						pq.Offer(v)
+					}
+				}
+			})
+			b.Run(fmt.Sprintf("n=%d/d=%d/offer+poll", n, d), func(b *testing.B) {
+				for b.Loop() {
+					pq := New(WithArity[int](d), WithCapacity[int](n))
+					for _, v := range items {
+						pq.Offer(v)
+					}
+					for !pq.IsEmpty() {
+						pq.Poll()
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkOfferAll(b *testing.B) {
+	const n = 10_000
+	heap := randomInts(n)
+	for _, k := range []int{10, 100, 1_000, 2_000, 5_000, 10_000, 100_000} {
+		// each element of the descending batch sorts before all of the others, so sifting it up reaches the root
+		descending := make([]int, k)
+		for i := range descending {
+			descending[i] = -i
+		}
+		for _, batch := range []struct {
+			name  string
+			items []int
+		}{
+			{"random", randomInts(k)},
+			{"descending", descending},
+		} {
+			for _, s := range []struct {
+				name string
+				push func(h *internalHeap[int], items []int)
+			}{
+				{"sift", siftEach},
+				{"heapify", heapify},
+				{"auto", (*internalHeap[int]).pushAll},
+			} {
+				b.Run(fmt.Sprintf("n=%d/k=%d/%s/%s", n, k, batch.name, s.name), func(b *testing.B) {
+					var compares int
+					for b.Loop() {
+						b.StopTimer()
+						h := newIntHeap(heap, 2, &compares)
+						h.items = append(make([]int, 0, n+k), h.items...)
+						compares = 0
+						b.StartTimer()
+						s.push(h, batch.items)
+					}
+					b.ReportMetric(float64(compares), "compares/op")
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package priorityqueue
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// MarshalJSON encodes the elements of this queue as a JSON array, in the order of the backing slice,
+// or in priority order with WithStableOrdering, so that decoding them keeps equal elements in their insertion order.
+func (pq *PriorityQueue[E]) MarshalJSON() ([]byte, error) {
+	if pq.heap == nil {
+		return json.Marshal([]E{})
+	}
+	return json.Marshal(append([]E{}, pq.orderedElements()...))
+}
+
+// UnmarshalJSON decodes a JSON array into this queue, replacing its elements.
+// The heap is rebuilt with the comparator of this queue, or the default comparator if the queue is a zero value.
+func (pq *PriorityQueue[E]) UnmarshalJSON(data []byte) error {
+	var items []E
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	return pq.load(items)
+}
+
+// MarshalBinary encodes the elements of this queue with encoding/gob, in the same order as MarshalJSON.
+// It also makes the queue usable as a value in gob streams.
+func (pq *PriorityQueue[E]) MarshalBinary() ([]byte, error) {
+	var items []E
+	if pq.heap != nil {
+		items = pq.orderedElements()
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(items); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary into this queue, replacing its elements.
+// The heap is rebuilt with the comparator of this queue, or the default comparator if the queue is a zero value.
+func (pq *PriorityQueue[E]) UnmarshalBinary(data []byte) error {
+	var items []E
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&items); err != nil {
+		return err
+	}
+	return pq.load(items)
+}
+
+// load replaces the elements of this queue with the given items and rebuilds the heap.
+func (pq *PriorityQueue[E]) load(items []E) error {
+	if pq.maxCapacity > 0 && len(items) > pq.maxCapacity {
+		return errors.New("priorityqueue: decoded elements exceed max capacity")
+	}
+
+	if pq.heap == nil {
+		pq.comparator = util.DefaultComparator[E]()
+		pq.heap = &internalHeap[E]{
+			comparator: pq.comparator,
+			arity:      2,
+		}
+	}
+	if pq.equals == nil {
+		pq.equals = defaultEquals(pq.compare, pq.deepEquals)
+		pq.equalsByDefault = true
+	}
+	if items == nil {
+		items = []E{}
+	}
+	// the elements deleted in lazy deletion mode go with the replaced elements
+	clear(pq.deleted)
+	pq.deletedCount = 0
+	if pq.topK > 0 {
+		pq.heap.setItems([]E{})
+		for _, item := range items {
+			pq.retainTopK(item)
+		}
+		return nil
+	}
+	pq.heap.setItems(items)
+	return nil
+}
@@ -0,0 +1,201 @@
+package priorityqueue
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// job is an element type with exported fields, so that it can be encoded.
+type job struct {
+	P    int
+	Name string
+}
+
+func byJobPriority(a, b job) int {
+	return cmp.Compare(a.P, b.P)
+}
+
+// newStableJobs returns a stable queue whose backing order differs from the insertion order of its tied elements.
+func newStableJobs(opts ...Option[job]) *PriorityQueue[job] {
+	pq := New(append([]Option[job]{WithComparator(byJobPriority), WithStableOrdering[job]()}, opts...)...)
+	pq.Add(job{0, "x"})
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		pq.Add(job{1, name})
+	}
+	pq.Poll()
+	return pq
+}
+
+// pollNames polls every element of the queue and concatenates their names.
+func pollNames(pq *PriorityQueue[job]) string {
+	var names string
+	for !pq.IsEmpty() {
+		names += pq.Poll().Name
+	}
+	return names
+}
+
+func TestStableJSONRoundTrip(t *testing.T) {
+	for name, opts := range map[string][]Option[job]{
+		"d-ary":   nil,
+		"pairing": {WithPairingHeap[job]()},
+	} {
+		data, err := json.Marshal(newStableJobs(opts...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := New(append([]Option[job]{WithComparator(byJobPriority), WithStableOrdering[job]()}, opts...)...)
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatal(err)
+		}
+		if got := pollNames(decoded); got != "abcdef" {
+			t.Errorf("%s: decoded queue polled %s, want abcdef", name, got)
+		}
+	}
+}
+
+func TestStableBinaryRoundTrip(t *testing.T) {
+	data, err := newStableJobs().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := New(WithComparator(byJobPriority), WithStableOrdering[job]())
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if got := pollNames(decoded); got != "abcdef" {
+		t.Errorf("decoded queue polled %s, want abcdef", got)
+	}
+}
+
+func TestJSONRoundTripCustomComparator(t *testing.T) {
+	byPriorityDesc := func(a, b job) int { return cmp.Compare(b.P, a.P) }
+	pq := New(WithComparator(byPriorityDesc), WithInitialItems(job{2, "b"}, job{5, "e"}, job{1, "a"}, job{4, "d"}))
+	data, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"Name":"e"`) {
+		t.Errorf("Marshal() = %s, want the fields of the elements", data)
+	}
+
+	decoded := New(WithComparator(byPriorityDesc))
+	decoded.Add(job{9, "stale"})
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := pollNames(decoded); got != "edba" {
+		t.Errorf("decoded queue polled %s, want edba", got)
+	}
+}
+
+func TestUnmarshalJSONExceedsMaxCapacity(t *testing.T) {
+	pq := New(WithMaxCapacity[int](2), WithInitialItems(7))
+	if err := json.Unmarshal([]byte("[3, 1, 2]"), pq); err == nil {
+		t.Fatal("Unmarshal() succeeded with more elements than the max capacity")
+	}
+	if got := pq.ToArray(); !slices.Equal(got, []int{7}) {
+		t.Errorf("ToArray() = %v after a rejected Unmarshal, want [7]", got)
+	}
+}
+
+func TestUnmarshalJSONZeroValue(t *testing.T) {
+	var saved struct {
+		Pending *PriorityQueue[int]
+		Done    PriorityQueue[int]
+	}
+	if err := json.Unmarshal([]byte(`{"Pending": [3, 1, 2], "Done": [5, 4]}`), &saved); err != nil {
+		t.Fatal(err)
+	}
+	if got := saved.Pending.ToSortedArray(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Pending = %v, want [1 2 3]", got)
+	}
+	if got := saved.Done.Poll(); got != 4 {
+		t.Errorf("Done.Poll() = %d, want 4", got)
+	}
+	saved.Done.Add(1)
+	if got := saved.Done.Peek(); got != 1 {
+		t.Errorf("Done.Peek() = %d after Add(1), want 1", got)
+	}
+
+	var empty PriorityQueue[int]
+	if data, err := json.Marshal(&empty); err != nil || string(data) != "[]" {
+		t.Errorf("Marshal(zero value) = %s, %v, want []", data, err)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for name, opts := range map[string][]Option[int]{
+		"d-ary":   {WithArity[int](4)},
+		"pairing": {WithPairingHeap[int]()},
+	} {
+		pq := New(append(opts, WithInitialItems(randomInts(100)...))...)
+		want := pq.ToSortedArray()
+		data, err := pq.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		decoded := New(opts...)
+		decoded.Add(-1)
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := decoded.ToSortedArray(); !slices.Equal(got, want) {
+			t.Errorf("%s: decoded %v, want %v", name, got, want)
+		}
+		if err := decoded.CheckInvariants(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestUnmarshalBinaryExceedsMaxCapacity(t *testing.T) {
+	data, err := New(WithInitialItems(3, 1, 2)).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pq := New(WithMaxCapacity[int](2), WithInitialItems(7))
+	if err := pq.UnmarshalBinary(data); err == nil {
+		t.Fatal("UnmarshalBinary() succeeded with more elements than the max capacity")
+	}
+	if got := pq.ToArray(); !slices.Equal(got, []int{7}) {
+		t.Errorf("ToArray() = %v after a rejected UnmarshalBinary, want [7]", got)
+	}
+}
+
+func TestGobStream(t *testing.T) {
+	type snapshot struct {
+		Name    string
+		Queue   PriorityQueue[int]
+		Pending *PriorityQueue[string]
+	}
+	in := snapshot{
+		Name:    "tasks",
+		Queue:   *New(WithInitialItems(5, 2, 8, 2)),
+		Pending: New(WithInitialItems("b", "c", "a")),
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+
+	var out snapshot
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "tasks" {
+		t.Errorf("Name = %q, want tasks", out.Name)
+	}
+	if got := out.Queue.ToSortedArray(); !slices.Equal(got, []int{2, 2, 5, 8}) {
+		t.Errorf("Queue = %v, want [2 2 5 8]", got)
+	}
+	if got := out.Pending.ToSortedArray(); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Pending = %v, want [a b c]", got)
+	}
+}
@@ -0,0 +1,120 @@
+package priorityqueue
+
+import (
+	"container/heap"
+)
+
+// heapAdapter is an adapter that exposes the backing slice of the d-ary heap of a PriorityQueue as a heap.Interface.
+type heapAdapter[E any] struct {
+	h *internalHeap[E]
+}
+
+// HeapInterface returns a heap.Interface backed by this queue, for use with the container/heap functions.
+// The adapter and the queue share their elements, so changes made through either are visible in the other.
+// The container/heap functions only maintain a binary heap, so HeapInterface panics if the queue was created
+// with WithArity greater than 2, WithPairingHeap, or WithLazyDeletion, whose deleted elements the functions would not skip.
+// Elements pushed and popped through the adapter bypass the queue: WithMaxCapacity and WithTopK are not enforced,
+// and observers are not notified. Push panics if it is given a value that is not of type E.
+func (pq *PriorityQueue[E]) HeapInterface() heap.Interface {
+	h, ok := pq.heap.(*internalHeap[E])
+	if !ok || h.arity != 2 {
+		panic("Heap interface requires a binary heap")
+	}
+	if pq.deleted != nil {
+		panic("Heap interface does not support lazy deletion")
+	}
+	return heapAdapter[E]{h: h}
+}
+
+// Len is the number of elements in the collection.
+func (a heapAdapter[E]) Len() int {
+	return a.h.Len()
+}
+
+// Less reports whether the element with index i should sort before the element with index j.
+func (a heapAdapter[E]) Less(i, j int) bool {
+	return a.h.Less(i, j)
+}
+
+// Swap swaps the elements with indexes i and j.
+func (a heapAdapter[E]) Swap(i, j int) {
+	a.h.Swap(i, j)
+}
+
+// Push appends x to the backing slice.
+// It is used by the heap package.
+func (a heapAdapter[E]) Push(x any) {
+	a.h.appendItems(x.(E))
+}
+
+// Pop removes and returns the last element of the backing slice.
+// It is used by the heap package.
+func (a heapAdapter[E]) Pop() any {
+	return a.h.removeLast()
+}
+
+// HeapQueue is a queue backed by an existing heap.Interface, with the Java-style queue methods.
+// It eases the migration of code built on container/heap.
+type HeapQueue[E any] struct {
+	h heap.Interface
+}
+
+// WrapHeap returns a HeapQueue backed by the given heap.Interface, whose Push and Pop methods must accept and return values of type E.
+// The heap is initialized with heap.Init.
+func WrapHeap[E any](h heap.Interface) *HeapQueue[E] {
+	heap.Init(h)
+	return &HeapQueue[E]{h: h}
+}
+
+// Inserts the specified element into this queue.
+// boolean add(E e)
+func (hq *HeapQueue[E]) Add(item E) bool {
+	return hq.Offer(item)
+}
+
+// Inserts the specified element into this queue.
+// boolean offer(E e)
+func (hq *HeapQueue[E]) Offer(item E) bool {
+	heap.Push(hq.h, item)
+	return true
+}
+
+// Retrieves and removes the head of this queue, or returns zero value if this queue is empty.
+// E poll()
+func (hq *HeapQueue[E]) Poll() E {
+	if hq.h.Len() == 0 {
+		var zero E
+		return zero
+	}
+	return heap.Pop(hq.h).(E)
+}
+
+// Retrieves, but does not remove, the head of this queue, or returns zero value if this queue is empty.
+// As heap.Interface gives no access to its elements, the head is popped and pushed back, in O(log n) time.
+// E peek()
+func (hq *HeapQueue[E]) Peek() E {
+	if hq.h.Len() == 0 {
+		var zero E
+		return zero
+	}
+	item := heap.Pop(hq.h).(E)
+	heap.Push(hq.h, item)
+	return item
+}
+
+// Returns true if this queue contains no elements.
+// boolean isEmpty()
+func (hq *HeapQueue[E]) IsEmpty() bool {
+	return hq.h.Len() == 0
+}
+
+// Returns the number of elements in this queue.
+// int size()
+func (hq *HeapQueue[E]) Size() int {
+	return hq.h.Len()
+}
+
+// Unwrap returns the underlying heap.Interface.
+func (hq *HeapQueue[E]) Unwrap() heap.Interface {
+	return hq.h
+}
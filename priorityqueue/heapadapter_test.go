@@ -0,0 +1,68 @@
+package priorityqueue
+
+import (
+	"container/heap"
+	"slices"
+	"testing"
+)
+
+func TestHeapInterface(t *testing.T) {
+	pq := New(WithInitialItems(5, 1, 4))
+	h := pq.HeapInterface()
+	heap.Push(h, 2)
+	heap.Push(h, 3)
+	if got := heap.Pop(h); got != 1 {
+		t.Errorf("heap.Pop() = %v, want 1", got)
+	}
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{2, 3, 4, 5}) {
+		t.Errorf("ToSortedArray() = %v, want [2 3 4 5]", got)
+	}
+	if err := pq.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHeapInterfaceRequiresBinaryHeap(t *testing.T) {
+	for name, opt := range map[string]Option[int]{
+		"arity":         WithArity[int](4),
+		"lazy deletion": WithLazyDeletion[int](),
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("HeapInterface did not panic with %s", name)
+				}
+			}()
+			New(opt).HeapInterface()
+		}()
+	}
+}
+
+func TestWrapHeap(t *testing.T) {
+	h := &intHeap{4, 2, 3}
+	hq := WrapHeap[int](h)
+	hq.Offer(1)
+	if got := hq.Peek(); got != 1 {
+		t.Errorf("Peek() = %d, want 1", got)
+	}
+	var polled []int
+	for !hq.IsEmpty() {
+		polled = append(polled, hq.Poll())
+	}
+	if !slices.Equal(polled, []int{1, 2, 3, 4}) {
+		t.Errorf("polled %v, want [1 2 3 4]", polled)
+	}
+}
+
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
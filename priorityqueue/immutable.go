@@ -0,0 +1,103 @@
+package priorityqueue
+
+import (
+	"iter"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// ImmutablePriorityQueue is a read-only snapshot of a PriorityQueue.
+// It has no mutating methods, and is safe for concurrent use by multiple goroutines.
+type ImmutablePriorityQueue[E any] struct {
+	pq *PriorityQueue[E]
+}
+
+// Freeze returns a read-only snapshot of this queue.
+// Later modifications of this queue are not reflected in the snapshot, and the observers of this queue are not kept.
+func (pq *PriorityQueue[E]) Freeze() ImmutablePriorityQueue[E] {
+	snapshot := pq.Clone()
+	snapshot.observers = nil
+	return ImmutablePriorityQueue[E]{pq: snapshot}
+}
+
+// Returns the comparator used to order the elements in this queue.
+// Comparator<? super E> comparator()
+func (ipq ImmutablePriorityQueue[E]) Comparator() util.Comparator[E] {
+	return ipq.pq.Comparator()
+}
+
+// Returns true if this queue contains the specified element.
+// boolean contains(Object o)
+func (ipq ImmutablePriorityQueue[E]) Contains(item E) bool {
+	return ipq.pq.Contains(item)
+}
+
+// Returns true if this queue contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (ipq ImmutablePriorityQueue[E]) ContainsAll(items []E) bool {
+	return ipq.pq.ContainsAll(items)
+}
+
+// Performs the given action for each element of this queue, in no particular order.
+// void forEach(Consumer<? super E> action)
+func (ipq ImmutablePriorityQueue[E]) ForEach(action util.Consumer[E]) {
+	ipq.pq.ForEach(action)
+}
+
+// All returns an iterator over the elements in this queue, for use with range-over-func.
+// The elements are returned in no particular order.
+func (ipq ImmutablePriorityQueue[E]) All() iter.Seq[E] {
+	return ipq.pq.All()
+}
+
+// Returns true if this queue contains no elements.
+// boolean isEmpty()
+func (ipq ImmutablePriorityQueue[E]) IsEmpty() bool {
+	return ipq.pq.IsEmpty()
+}
+
+// NSmallest returns at most k elements from the head of this queue, in priority order.
+func (ipq ImmutablePriorityQueue[E]) NSmallest(k int) []E {
+	return ipq.pq.NSmallest(k)
+}
+
+// Retrieves the head of this queue, or returns zero value if this queue is empty.
+// E peek()
+func (ipq ImmutablePriorityQueue[E]) Peek() E {
+	return ipq.pq.Peek()
+}
+
+// PeekOK retrieves the head of this queue.
+// The boolean result is false if this queue is empty.
+func (ipq ImmutablePriorityQueue[E]) PeekOK() (E, bool) {
+	return ipq.pq.PeekOK()
+}
+
+// Returns the number of elements in this queue.
+// int size()
+func (ipq ImmutablePriorityQueue[E]) Size() int {
+	return ipq.pq.Size()
+}
+
+// Returns an array containing all of the elements in this queue.
+// Object[] toArray()
+func (ipq ImmutablePriorityQueue[E]) ToArray() []E {
+	return ipq.pq.ToArray()
+}
+
+// Returns an array containing all of the elements in this queue, in priority order.
+func (ipq ImmutablePriorityQueue[E]) ToSortedArray() []E {
+	return ipq.pq.ToSortedArray()
+}
+
+// Returns a string representation of this queue, in the form "[e1, e2, e3]".
+// String toString()
+func (ipq ImmutablePriorityQueue[E]) String() string {
+	return ipq.pq.String()
+}
+
+// Thaw returns a new mutable PriorityQueue containing the elements of this snapshot.
+// The new queue has no observers, so the observers of the frozen queue are not notified of its activity.
+func (ipq ImmutablePriorityQueue[E]) Thaw() *PriorityQueue[E] {
+	return ipq.pq.Clone()
+}
@@ -0,0 +1,36 @@
+package priorityqueue
+
+import (
+	"testing"
+)
+
+func TestCloneCopiesObservers(t *testing.T) {
+	var offered int
+	pq := New(WithObserver(Observer[int]{OnOffer: func(int) { offered++ }}))
+	clone := pq.Clone()
+	clone.observers = append(clone.observers, Observer[int]{})
+	if len(pq.observers) != 1 {
+		t.Errorf("the source has %d observers after appending to the clone, want 1", len(pq.observers))
+	}
+	clone.Add(1)
+	if offered != 1 {
+		t.Errorf("OnOffer called %d times for the clone, want 1", offered)
+	}
+}
+
+func TestThawHasNoObservers(t *testing.T) {
+	var offered int
+	pq := New(WithObserver(Observer[int]{OnOffer: func(int) { offered++ }}), WithInitialItems(3, 1))
+	frozen := pq.Freeze()
+	thawed := frozen.Thaw()
+	thawed.Add(2)
+	if offered != 0 {
+		t.Errorf("OnOffer of the frozen queue called %d times by the thawed queue, want 0", offered)
+	}
+	if got := frozen.Size(); got != 2 {
+		t.Errorf("frozen Size() = %d, want 2", got)
+	}
+	if got := thawed.Peek(); got != 1 {
+		t.Errorf("thawed Peek() = %d, want 1", got)
+	}
+}
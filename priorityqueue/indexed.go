@@ -0,0 +1,188 @@
+package priorityqueue
+
+import (
+	"fmt"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// IndexedPriorityQueue is a priority queue that associates each element with an integer index between 0 and maxN-1.
+// The position of every index in the heap is tracked, so the priority of an element can be changed in O(log n).
+// It is useful for algorithms such as Dijkstra and A*, where the indexes are vertex numbers.
+type IndexedPriorityQueue[E any] struct {
+	// heap is the heap of indexes, ordered by the elements associated with them.
+	heap *internalHeap[int]
+
+	// positions is the inverse of heap.items: positions[heap.items[p]] == p, or -1 if the index is not in the heap.
+	positions []int
+
+	// items holds the element associated with each index.
+	items []E
+
+	comparator util.Comparator[E]
+}
+
+// NewIndexed creates a new IndexedPriorityQueue that accepts indexes between 0 and maxN-1.
+// The comparator, reverse order and arity options are used; the others have no effect.
+func NewIndexed[E any](maxN int, opts ...Option[E]) *IndexedPriorityQueue[E] {
+	if maxN < 0 {
+		panic("Illegal capacity")
+	}
+
+	positions := make([]int, maxN)
+	for i := range positions {
+		positions[i] = -1
+	}
+
+	settings := resolveOptions(opts)
+	ipq := &IndexedPriorityQueue[E]{
+		positions:  positions,
+		items:      make([]E, maxN),
+		comparator: settings.comparator,
+	}
+	ipq.heap = &internalHeap[int]{
+		items: make([]int, 0, maxN),
+		comparator: func(i, j int) int {
+			return ipq.comparator(ipq.items[i], ipq.items[j])
+		},
+		arity: settings.arity,
+		moved: func(i, p int) {
+			ipq.positions[i] = p
+		},
+	}
+	return ipq
+}
+
+// checkIndex panics if i is not a valid index.
+func (ipq *IndexedPriorityQueue[E]) checkIndex(i int) {
+	if err := util.CheckIndex(i, len(ipq.positions)); err != nil {
+		panic(err.Error())
+	}
+}
+
+// checkContains panics if i is not associated with an element.
+func (ipq *IndexedPriorityQueue[E]) checkContains(i int) {
+	if !ipq.Contains(i) {
+		panic("Index is not in the queue")
+	}
+}
+
+// Inserts the specified element into this queue and associates it with index i.
+func (ipq *IndexedPriorityQueue[E]) Insert(i int, item E) {
+	ipq.checkIndex(i)
+	if ipq.Contains(i) {
+		panic("Index is already in the queue")
+	}
+	ipq.items[i] = item
+	ipq.heap.push(i)
+}
+
+// Returns true if index i is associated with an element in this queue.
+func (ipq *IndexedPriorityQueue[E]) Contains(i int) bool {
+	ipq.checkIndex(i)
+	return ipq.positions[i] != -1
+}
+
+// Returns the element associated with index i.
+func (ipq *IndexedPriorityQueue[E]) KeyOf(i int) E {
+	ipq.checkIndex(i)
+	ipq.checkContains(i)
+	return ipq.items[i]
+}
+
+// Retrieves, but does not remove, the head of this queue and its index.
+// Returns -1 and zero value if this queue is empty.
+func (ipq *IndexedPriorityQueue[E]) Peek() (int, E) {
+	if ipq.Size() == 0 {
+		var zero E
+		return -1, zero
+	}
+	i := ipq.heap.items[0]
+	return i, ipq.items[i]
+}
+
+// Retrieves and removes the head of this queue and its index.
+// Returns -1 and zero value if this queue is empty.
+func (ipq *IndexedPriorityQueue[E]) Poll() (int, E) {
+	if ipq.Size() == 0 {
+		var zero E
+		return -1, zero
+	}
+	i := ipq.heap.pop()
+	return i, ipq.detach(i)
+}
+
+// Removes the element associated with index i and returns it.
+func (ipq *IndexedPriorityQueue[E]) Delete(i int) E {
+	ipq.checkIndex(i)
+	ipq.checkContains(i)
+	ipq.heap.remove(ipq.positions[i])
+	return ipq.detach(i)
+}
+
+// UpdatePriority replaces the element associated with index i and restores the heap order in O(log n).
+func (ipq *IndexedPriorityQueue[E]) UpdatePriority(i int, item E) {
+	ipq.checkIndex(i)
+	ipq.checkContains(i)
+	ipq.items[i] = item
+	ipq.heap.fix(ipq.positions[i])
+}
+
+// DecreaseKey replaces the element associated with index i with an element that sorts strictly before it.
+func (ipq *IndexedPriorityQueue[E]) DecreaseKey(i int, item E) {
+	ipq.checkIndex(i)
+	ipq.checkContains(i)
+	if ipq.comparator(item, ipq.items[i]) >= 0 {
+		panic("Key is not less than the current key")
+	}
+	ipq.UpdatePriority(i, item)
+}
+
+// IncreaseKey replaces the element associated with index i with an element that sorts strictly after it.
+func (ipq *IndexedPriorityQueue[E]) IncreaseKey(i int, item E) {
+	ipq.checkIndex(i)
+	ipq.checkContains(i)
+	if ipq.comparator(item, ipq.items[i]) <= 0 {
+		panic("Key is not greater than the current key")
+	}
+	ipq.UpdatePriority(i, item)
+}
+
+// Returns the comparator used to order the elements in this queue.
+func (ipq *IndexedPriorityQueue[E]) Comparator() util.Comparator[E] {
+	return ipq.comparator
+}
+
+// Returns the number of elements in this queue.
+func (ipq *IndexedPriorityQueue[E]) Size() int {
+	return ipq.heap.Len()
+}
+
+// CheckInvariants verifies the heap property and the consistency of the index positions,
+// and returns an error describing the first violation.
+func (ipq *IndexedPriorityQueue[E]) CheckInvariants() error {
+	for p, i := range ipq.heap.items {
+		if ipq.positions[i] != p {
+			return fmt.Errorf("priorityqueue: index %d is at heap position %d but its recorded position is %d", i, p, ipq.positions[i])
+		}
+	}
+	count := 0
+	for _, p := range ipq.positions {
+		if p != -1 {
+			count++
+		}
+	}
+	if count != ipq.heap.Len() {
+		return fmt.Errorf("priorityqueue: %d recorded positions for %d indexes", count, ipq.heap.Len())
+	}
+	return ipq.heap.check()
+}
+
+// detach clears the element associated with index i, which has already been removed from the heap, and returns it.
+func (ipq *IndexedPriorityQueue[E]) detach(i int) E {
+	var zero E
+	item := ipq.items[i]
+	ipq.items[i] = zero
+	ipq.positions[i] = -1
+	return item
+}
@@ -0,0 +1,66 @@
+package priorityqueue
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	for _, arity := range []int{2, 4} {
+		rnd := rand.New(rand.NewPCG(1, uint64(arity)))
+		const n = 200
+		ipq := NewIndexed(n, WithArity[int](arity))
+		keys := make(map[int]int)
+		for range 2000 {
+			i := rnd.IntN(n)
+			switch {
+			case !ipq.Contains(i):
+				k := rnd.IntN(1000)
+				ipq.Insert(i, k)
+				keys[i] = k
+			case rnd.IntN(3) == 0:
+				if got := ipq.Delete(i); got != keys[i] {
+					t.Fatalf("Delete(%d) = %d, want %d", i, got, keys[i])
+				}
+				delete(keys, i)
+			default:
+				k := rnd.IntN(1000)
+				ipq.UpdatePriority(i, k)
+				keys[i] = k
+			}
+			if err := ipq.CheckInvariants(); err != nil {
+				t.Fatalf("arity %d: %v", arity, err)
+			}
+		}
+
+		last := -1
+		for ipq.Size() > 0 {
+			i, k := ipq.Poll()
+			if k != keys[i] || k < last {
+				t.Fatalf("arity %d: Poll() = %d, %d out of order or with a stale key %d", arity, i, k, keys[i])
+			}
+			delete(keys, i)
+			last = k
+		}
+		if len(keys) != 0 {
+			t.Errorf("arity %d: %d indexes were never polled", arity, len(keys))
+		}
+	}
+}
+
+func TestIndexedDecreaseKey(t *testing.T) {
+	ipq := NewIndexed[int](3)
+	ipq.Insert(0, 10)
+	ipq.Insert(1, 20)
+	ipq.Insert(2, 30)
+	ipq.DecreaseKey(2, 5)
+	if i, k := ipq.Peek(); i != 2 || k != 5 {
+		t.Errorf("Peek() = %d, %d, want 2, 5", i, k)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("DecreaseKey with a greater key did not panic")
+		}
+	}()
+	ipq.DecreaseKey(0, 15)
+}
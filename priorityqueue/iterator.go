@@ -0,0 +1,158 @@
+package priorityqueue
+
+import (
+	"iter"
+)
+
+// Iterator is an iterator over the elements of a PriorityQueue.
+// The elements are returned in no particular order.
+type Iterator[E any] struct {
+	pq *PriorityQueue[E]
+
+	// heap is the d-ary heap of the queue, or nil for a pairing heap, whose elements are all taken from forgetMeNot.
+	heap *internalHeap[E]
+
+	// cursor is the index of the element to be returned by the next call to Next.
+	cursor int
+
+	// lastRet is the index of the element returned by the most recent call to Next, or -1 if there is no such element.
+	lastRet int
+
+	// forgetMeNot holds the elements that were moved from the unvisited portion of the heap into the visited portion
+	// as a result of a removal during iteration, or for a pairing heap, the elements it held when the iteration started.
+	forgetMeNot []E
+
+	// lastRetElt is the element returned by the most recent call to Next when that element was taken from forgetMeNot.
+	lastRetElt    E
+	hasLastRetElt bool
+}
+
+// Returns an iterator over the elements in this queue.
+// Iterator<E> iterator()
+func (pq *PriorityQueue[E]) Iterator() *Iterator[E] {
+	it := &Iterator[E]{
+		pq:      pq,
+		lastRet: -1,
+	}
+	if h, ok := pq.heap.(*internalHeap[E]); ok {
+		pq.purge()
+		it.heap = h
+	} else {
+		it.forgetMeNot = pq.elements()
+	}
+	return it
+}
+
+// All returns an iterator over the elements in this queue, for use with range-over-func.
+// The elements are returned in no particular order.
+func (pq *PriorityQueue[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, v := range pq.elements() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns true if the iteration has more elements.
+// boolean hasNext()
+func (it *Iterator[E]) HasNext() bool {
+	return it.unvisited() || len(it.forgetMeNot) > 0
+}
+
+// Returns the next element in the iteration.
+// E next()
+func (it *Iterator[E]) Next() E {
+	if it.unvisited() {
+		it.lastRet = it.cursor
+		it.cursor++
+		return it.heap.items[it.lastRet]
+	}
+
+	if len(it.forgetMeNot) > 0 {
+		it.lastRet = -1
+		it.lastRetElt = it.forgetMeNot[0]
+		it.hasLastRetElt = true
+		it.forgetMeNot = it.forgetMeNot[1:]
+		return it.lastRetElt
+	}
+
+	panic("No such element")
+}
+
+// Removes from the underlying queue the last element returned by this iterator.
+// void remove()
+func (it *Iterator[E]) Remove() {
+	if it.lastRet != -1 {
+		moved, movedUp := it.pq.removeAt(it.heap, it.lastRet)
+		it.lastRet = -1
+		if movedUp {
+			it.forgetMeNot = append(it.forgetMeNot, moved)
+		} else {
+			it.cursor--
+		}
+		return
+	}
+
+	if it.hasLastRetElt {
+		it.pq.Remove(it.lastRetElt)
+		var zero E
+		it.lastRetElt = zero
+		it.hasLastRetElt = false
+		return
+	}
+
+	panic("Illegal state")
+}
+
+// unvisited reports whether the backing slice of the d-ary heap has elements that have not been returned yet.
+func (it *Iterator[E]) unvisited() bool {
+	return it.heap != nil && it.cursor < it.heap.Len()
+}
+
+// SnapshotIterator is a weakly consistent iterator over a thread-safe queue.
+// It traverses a copy of the elements taken when it was created, so it never blocks writers
+// and does not reflect modifications made after its creation.
+type SnapshotIterator[E any] struct {
+	items   []E
+	cursor  int
+	lastRet int
+	remove  func(E) bool
+}
+
+// newSnapshotIterator creates a SnapshotIterator over items that removes elements with the given function.
+func newSnapshotIterator[E any](items []E, remove func(E) bool) *SnapshotIterator[E] {
+	return &SnapshotIterator[E]{
+		items:   items,
+		lastRet: -1,
+		remove:  remove,
+	}
+}
+
+// Returns true if the iteration has more elements.
+// boolean hasNext()
+func (it *SnapshotIterator[E]) HasNext() bool {
+	return it.cursor < len(it.items)
+}
+
+// Returns the next element in the iteration.
+// E next()
+func (it *SnapshotIterator[E]) Next() E {
+	if it.cursor >= len(it.items) {
+		panic("No such element")
+	}
+	it.lastRet = it.cursor
+	it.cursor++
+	return it.items[it.lastRet]
+}
+
+// Removes from the underlying queue an element equal to the last element returned by this iterator, if it is still present.
+// void remove()
+func (it *SnapshotIterator[E]) Remove() {
+	if it.lastRet < 0 {
+		panic("Illegal state")
+	}
+	it.remove(it.items[it.lastRet])
+	it.lastRet = -1
+}
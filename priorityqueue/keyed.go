@@ -0,0 +1,182 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// KeyedPriorityQueue is a priority queue whose elements are identified by unique keys.
+// The position of every key in the heap is tracked in a map, so elements can be looked up by key in O(1) time,
+// and removed or updated by key in O(log n) time.
+type KeyedPriorityQueue[K comparable, E any] struct {
+	heap *internalHeap[keyedEntry[K, E]]
+
+	// positions maps each key to the heap position of its entry.
+	positions map[K]int
+
+	comparator util.Comparator[E]
+}
+
+// keyedEntry is an element of a KeyedPriorityQueue together with its key.
+type keyedEntry[K comparable, E any] struct {
+	key  K
+	item E
+}
+
+// NewKeyed creates a new KeyedPriorityQueue with the given options.
+// The comparator, reverse order and arity options are used; the others have no effect.
+func NewKeyed[K comparable, E any](opts ...Option[E]) *KeyedPriorityQueue[K, E] {
+	settings := resolveOptions(opts)
+	kpq := &KeyedPriorityQueue[K, E]{
+		positions:  make(map[K]int),
+		comparator: settings.comparator,
+	}
+	kpq.heap = &internalHeap[keyedEntry[K, E]]{
+		comparator: func(a, b keyedEntry[K, E]) int {
+			return kpq.comparator(a.item, b.item)
+		},
+		arity: settings.arity,
+		moved: func(e keyedEntry[K, E], p int) {
+			kpq.positions[e.key] = p
+		},
+	}
+	return kpq
+}
+
+// Offer inserts the specified element with the given key.
+// It returns false, and leaves the queue unchanged, if the key is already present.
+func (kpq *KeyedPriorityQueue[K, E]) Offer(key K, item E) bool {
+	if kpq.ContainsKey(key) {
+		return false
+	}
+	kpq.heap.push(keyedEntry[K, E]{key: key, item: item})
+	return true
+}
+
+// Put inserts the specified element with the given key, replacing the element previously associated with the key.
+func (kpq *KeyedPriorityQueue[K, E]) Put(key K, item E) {
+	if !kpq.UpdateByKey(key, item) {
+		kpq.heap.push(keyedEntry[K, E]{key: key, item: item})
+	}
+}
+
+// ContainsKey returns true if this queue contains an element with the given key.
+func (kpq *KeyedPriorityQueue[K, E]) ContainsKey(key K) bool {
+	_, ok := kpq.positions[key]
+	return ok
+}
+
+// Get returns the element associated with the given key.
+// The boolean result is false if the key is not present.
+func (kpq *KeyedPriorityQueue[K, E]) Get(key K) (E, bool) {
+	p, ok := kpq.positions[key]
+	if !ok {
+		var zero E
+		return zero, false
+	}
+	return kpq.heap.items[p].item, true
+}
+
+// UpdateByKey replaces the element associated with the given key and restores the heap order in O(log n).
+// It returns false if the key is not present.
+func (kpq *KeyedPriorityQueue[K, E]) UpdateByKey(key K, item E) bool {
+	p, ok := kpq.positions[key]
+	if !ok {
+		return false
+	}
+	kpq.heap.items[p].item = item
+	kpq.heap.fix(p)
+	return true
+}
+
+// RemoveKey removes the element associated with the given key and returns it.
+// The boolean result is false if the key is not present.
+func (kpq *KeyedPriorityQueue[K, E]) RemoveKey(key K) (E, bool) {
+	p, ok := kpq.positions[key]
+	if !ok {
+		var zero E
+		return zero, false
+	}
+	return kpq.detach(kpq.heap.remove(p)), true
+}
+
+// Retrieves, but does not remove, the head of this queue and its key.
+// Returns zero values if this queue is empty.
+func (kpq *KeyedPriorityQueue[K, E]) Peek() (K, E) {
+	if kpq.Size() == 0 {
+		var zeroKey K
+		var zero E
+		return zeroKey, zero
+	}
+	head := kpq.heap.items[0]
+	return head.key, head.item
+}
+
+// Retrieves and removes the head of this queue and its key.
+// Returns zero values if this queue is empty.
+func (kpq *KeyedPriorityQueue[K, E]) Poll() (K, E) {
+	if kpq.Size() == 0 {
+		var zeroKey K
+		var zero E
+		return zeroKey, zero
+	}
+	head := kpq.heap.pop()
+	return head.key, kpq.detach(head)
+}
+
+// All returns an iterator over the keys and elements in this queue, in no particular order.
+func (kpq *KeyedPriorityQueue[K, E]) All() iter.Seq2[K, E] {
+	return func(yield func(K, E) bool) {
+		for _, e := range kpq.heap.items {
+			if !yield(e.key, e.item) {
+				return
+			}
+		}
+	}
+}
+
+// Removes all of the elements from this queue.
+// void clear()
+func (kpq *KeyedPriorityQueue[K, E]) Clear() {
+	kpq.heap.items = nil
+	clear(kpq.positions)
+}
+
+// Returns the comparator used to order the elements in this queue.
+func (kpq *KeyedPriorityQueue[K, E]) Comparator() util.Comparator[E] {
+	return kpq.comparator
+}
+
+// Returns true if this queue contains no elements.
+// boolean isEmpty()
+func (kpq *KeyedPriorityQueue[K, E]) IsEmpty() bool {
+	return kpq.heap.Len() == 0
+}
+
+// Returns the number of elements in this queue.
+// int size()
+func (kpq *KeyedPriorityQueue[K, E]) Size() int {
+	return kpq.heap.Len()
+}
+
+// CheckInvariants verifies the heap property and the consistency of the key positions,
+// and returns an error describing the first violation.
+func (kpq *KeyedPriorityQueue[K, E]) CheckInvariants() error {
+	if len(kpq.positions) != kpq.heap.Len() {
+		return fmt.Errorf("priorityqueue: %d recorded positions for %d entries", len(kpq.positions), kpq.heap.Len())
+	}
+	for p, e := range kpq.heap.items {
+		if kpq.positions[e.key] != p {
+			return fmt.Errorf("priorityqueue: key %v is at heap position %d but its recorded position is %d", e.key, p, kpq.positions[e.key])
+		}
+	}
+	return kpq.heap.check()
+}
+
+// detach forgets the position of the entry, which has already been removed from the heap, and returns its element.
+func (kpq *KeyedPriorityQueue[K, E]) detach(e keyedEntry[K, E]) E {
+	delete(kpq.positions, e.key)
+	return e.item
+}
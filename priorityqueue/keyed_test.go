@@ -0,0 +1,50 @@
+package priorityqueue
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestKeyedPriorityQueue(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(2, 3))
+	kpq := NewKeyed[string, int](WithReverseOrder[int]())
+	want := make(map[string]int)
+	for range 2000 {
+		key := string(rune('a' + rnd.IntN(26)))
+		switch rnd.IntN(3) {
+		case 0:
+			item := rnd.IntN(1000)
+			kpq.Put(key, item)
+			want[key] = item
+		case 1:
+			got, ok := kpq.RemoveKey(key)
+			if w, present := want[key]; ok != present || got != w {
+				t.Fatalf("RemoveKey(%q) = %d, %t, want %d, %t", key, got, ok, w, present)
+			}
+			delete(want, key)
+		default:
+			item := rnd.IntN(1000)
+			if _, present := want[key]; kpq.Offer(key, item) == present {
+				t.Fatalf("Offer(%q) succeeded although the key was present, or failed although it was absent", key)
+			} else if !present {
+				want[key] = item
+			}
+		}
+		if err := kpq.CheckInvariants(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	last := 1000
+	for !kpq.IsEmpty() {
+		key, item := kpq.Poll()
+		if item != want[key] || item > last {
+			t.Fatalf("Poll() = %q, %d out of order or with a stale element %d", key, item, want[key])
+		}
+		delete(want, key)
+		last = item
+	}
+	if len(want) != 0 {
+		t.Errorf("%d keys were never polled", len(want))
+	}
+}
@@ -0,0 +1,137 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+type task struct {
+	p    int
+	name string
+}
+
+func byPriority(a, b task) int {
+	return cmp.Compare(a.p, b.p)
+}
+
+func TestLazyDeletionTiedElements(t *testing.T) {
+	pq := New(WithComparator(byPriority), WithLazyDeletion[task]())
+	pq.AddAll([]task{{1, "a"}, {1, "b"}, {1, "c"}})
+	pq.Remove(task{1, "b"})
+	pq.Remove(task{1, "c"})
+
+	if got := pq.Poll(); got != (task{1, "a"}) {
+		t.Errorf("Poll() = %v, want {1 a}", got)
+	}
+	if got, ok := pq.PollOK(); ok {
+		t.Errorf("PollOK() = %v, true, want an empty queue", got)
+	}
+	if got := pq.Size(); got != 0 {
+		t.Errorf("Size() = %d, want 0", got)
+	}
+	if err := pq.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLazyDeletionRemoveAbsent(t *testing.T) {
+	pq := New(WithLazyDeletion[int](), WithInitialItems(1, 2))
+	if pq.Remove(99) {
+		t.Error("Remove(99) = true, want false")
+	}
+	if !pq.Remove(2) {
+		t.Error("Remove(2) = false, want true")
+	}
+	if pq.Remove(2) {
+		t.Error("second Remove(2) = true, want false")
+	}
+	if got := pq.Size(); got != 1 {
+		t.Errorf("Size() = %d, want 1", got)
+	}
+	if err := pq.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLazyDeletionDuplicates(t *testing.T) {
+	pq := New(WithLazyDeletion[int](), WithInitialItems(5, 3, 5, 1, 5))
+	pq.Remove(5)
+	pq.Remove(5)
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{1, 3, 5}) {
+		t.Errorf("ToSortedArray() = %v, want [1 3 5]", got)
+	}
+	if !pq.Remove(5) || pq.Remove(5) {
+		t.Error("Remove(5) must succeed once more and then fail")
+	}
+	var polled []int
+	for !pq.IsEmpty() {
+		polled = append(polled, pq.Poll())
+	}
+	if !slices.Equal(polled, []int{1, 3}) {
+		t.Errorf("polled %v, want [1 3]", polled)
+	}
+}
+
+func TestLazyDeletionFromOtherQueue(t *testing.T) {
+	pq := New(WithInitialItems(1, 2, 3))
+	other := New(WithLazyDeletion[int](), WithInitialItems(1, 2))
+	other.Remove(1)
+
+	if !pq.RemoveAllFrom(other) {
+		t.Fatal("RemoveAllFrom() = false, want true")
+	}
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("after RemoveAllFrom, ToSortedArray() = %v, want [1 3]", got)
+	}
+
+	other.Remove(2)
+	other.Add(3)
+	pq.RetainAllFrom(other)
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{3}) {
+		t.Errorf("after RetainAllFrom, ToSortedArray() = %v, want [3]", got)
+	}
+}
+
+func TestLazyDeletionUnmarshal(t *testing.T) {
+	pq := New(WithLazyDeletion[int](), WithInitialItems(1, 2))
+	pq.Remove(1)
+	if err := json.Unmarshal([]byte("[1, 2, 3]"), pq); err != nil {
+		t.Fatal(err)
+	}
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("ToSortedArray() = %v, want [1 2 3]", got)
+	}
+	if err := pq.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLazyDeletionIterator(t *testing.T) {
+	pq := New(WithLazyDeletion[int](), WithInitialItems(1, 2, 3, 4))
+	pq.Remove(3)
+	it := pq.Iterator()
+	var seen []int
+	for it.HasNext() {
+		v := it.Next()
+		seen = append(seen, v)
+		if v == 1 {
+			// a deletion during the iteration keeps the deleted element in the heap until it is compacted
+			pq.Remove(4)
+		}
+	}
+	slices.Sort(seen)
+	if !slices.Equal(seen, []int{1, 2, 4}) {
+		t.Errorf("iterated %v, want [1 2 4]", seen)
+	}
+}
+
+func TestLazyDeletionRequiresComparable(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("New did not panic for a slice element type")
+		}
+	}()
+	New(WithComparator(func(a, b []int) int { return cmp.Compare(len(a), len(b)) }), WithLazyDeletion[[]int]())
+}
@@ -0,0 +1,325 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// MinMaxPriorityQueue is a double-ended priority queue backed by a min-max heap.
+// Both the least and the greatest element can be retrieved in O(1) time and removed in O(log n) time.
+// When a maximum capacity is set with WithMaxCapacity, inserting into a full queue evicts the greatest element,
+// which makes it suitable for retaining the best k elements.
+type MinMaxPriorityQueue[E any] struct {
+	items       []E
+	comparator  util.Comparator[E]
+	equals      util.Equals[E]
+	formatter   func(E) string
+	maxCapacity int
+}
+
+// NewMinMax creates a new MinMaxPriorityQueue with the given options.
+// The capacity, max capacity, comparator, reverse order, equals and formatter options are used; the others have no effect.
+func NewMinMax[E any](opts ...Option[E]) *MinMaxPriorityQueue[E] {
+	pq := resolveOptions(opts)
+	return &MinMaxPriorityQueue[E]{
+		items:       make([]E, 0, pq.capacity),
+		comparator:  pq.comparator,
+		equals:      pq.equals,
+		formatter:   pq.formatter,
+		maxCapacity: pq.maxCapacity,
+	}
+}
+
+// Inserts the specified element into this queue.
+// If the queue is full, the greatest element is evicted, which may be the specified element itself.
+// boolean add(E e)
+func (pq *MinMaxPriorityQueue[E]) Add(item E) bool {
+	pq.Offer(item)
+	return true
+}
+
+// Inserts the specified element into this queue.
+// If the queue is full, the greatest element is evicted. Returns false if the evicted element is the specified element itself.
+// boolean offer(E e)
+func (pq *MinMaxPriorityQueue[E]) Offer(item E) bool {
+	if pq.maxCapacity > 0 && len(pq.items) >= pq.maxCapacity {
+		if pq.comparator(item, pq.PeekLast()) >= 0 {
+			return false
+		}
+		pq.PollLast()
+	}
+
+	pq.items = append(pq.items, item)
+	pq.pushUp(len(pq.items) - 1)
+	return true
+}
+
+// Removes all of the elements from this queue.
+// void clear()
+func (pq *MinMaxPriorityQueue[E]) Clear() {
+	clear(pq.items)
+	pq.items = pq.items[:0]
+}
+
+// Returns the comparator used to order the elements in this queue.
+// Comparator<? super E> comparator()
+func (pq *MinMaxPriorityQueue[E]) Comparator() util.Comparator[E] {
+	return pq.comparator
+}
+
+// Returns true if this queue contains the specified element.
+// boolean contains(Object o)
+func (pq *MinMaxPriorityQueue[E]) Contains(item E) bool {
+	return pq.indexOf(item) >= 0
+}
+
+// Performs the given action for each element of this queue, in no particular order.
+// void forEach(Consumer<? super E> action)
+func (pq *MinMaxPriorityQueue[E]) ForEach(action util.Consumer[E]) {
+	for _, v := range pq.items {
+		action(v)
+	}
+}
+
+// All returns an iterator over the elements in this queue, for use with range-over-func.
+// The elements are returned in no particular order.
+func (pq *MinMaxPriorityQueue[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, v := range pq.items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Retrieves, but does not remove, the least element of this queue, or returns zero value if this queue is empty.
+// E peekFirst()
+func (pq *MinMaxPriorityQueue[E]) PeekFirst() E {
+	if len(pq.items) == 0 {
+		var zero E
+		return zero
+	}
+	return pq.items[0]
+}
+
+// Retrieves, but does not remove, the greatest element of this queue, or returns zero value if this queue is empty.
+// E peekLast()
+func (pq *MinMaxPriorityQueue[E]) PeekLast() E {
+	if len(pq.items) == 0 {
+		var zero E
+		return zero
+	}
+	return pq.items[pq.maxIndex()]
+}
+
+// Retrieves and removes the least element of this queue, or returns zero value if this queue is empty.
+// E pollFirst()
+func (pq *MinMaxPriorityQueue[E]) PollFirst() E {
+	if len(pq.items) == 0 {
+		var zero E
+		return zero
+	}
+	return pq.removeAt(0)
+}
+
+// Retrieves and removes the greatest element of this queue, or returns zero value if this queue is empty.
+// E pollLast()
+func (pq *MinMaxPriorityQueue[E]) PollLast() E {
+	if len(pq.items) == 0 {
+		var zero E
+		return zero
+	}
+	return pq.removeAt(pq.maxIndex())
+}
+
+// Retrieves, but does not remove, the least element of this queue, or returns zero value if this queue is empty.
+// E peek()
+func (pq *MinMaxPriorityQueue[E]) Peek() E {
+	return pq.PeekFirst()
+}
+
+// Retrieves and removes the least element of this queue, or returns zero value if this queue is empty.
+// E poll()
+func (pq *MinMaxPriorityQueue[E]) Poll() E {
+	return pq.PollFirst()
+}
+
+// Removes the specified element from this queue if it is present.
+// boolean remove(Object o)
+func (pq *MinMaxPriorityQueue[E]) Remove(item E) bool {
+	i := pq.indexOf(item)
+	if i < 0 {
+		return false
+	}
+	pq.removeAt(i)
+	return true
+}
+
+// Returns the number of elements in this queue.
+// int size()
+func (pq *MinMaxPriorityQueue[E]) Size() int {
+	return len(pq.items)
+}
+
+// Returns an array containing all of the elements in this queue.
+// Object[] toArray()
+func (pq *MinMaxPriorityQueue[E]) ToArray() []E {
+	return append([]E(nil), pq.items...)
+}
+
+// Returns a string representation of this queue, in the form "[e1, e2, e3]".
+// String toString()
+func (pq *MinMaxPriorityQueue[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, v := range pq.items {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if pq.formatter != nil {
+			sb.WriteString(pq.formatter(v))
+		} else {
+			fmt.Fprint(&sb, v)
+		}
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// indexOf returns the index of the first occurrence of item, or -1 if it is not present.
+func (pq *MinMaxPriorityQueue[E]) indexOf(item E) int {
+	for i, v := range pq.items {
+		if pq.equals(v, item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// maxIndex returns the index of the greatest element of the non-empty heap.
+func (pq *MinMaxPriorityQueue[E]) maxIndex() int {
+	switch len(pq.items) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if pq.less(1, 2) {
+		return 2
+	}
+	return 1
+}
+
+// removeAt removes and returns the element at index i.
+func (pq *MinMaxPriorityQueue[E]) removeAt(i int) E {
+	item := pq.items[i]
+	n := len(pq.items) - 1
+	pq.items[i] = pq.items[n]
+	var zero E
+	pq.items[n] = zero
+	pq.items = pq.items[:n]
+	if i < n {
+		pq.pushUp(pq.pushDown(i))
+	}
+	return item
+}
+
+// less reports whether the element with index i should sort before the element with index j.
+func (pq *MinMaxPriorityQueue[E]) less(i, j int) bool {
+	return pq.comparator(pq.items[i], pq.items[j]) < 0
+}
+
+// swap swaps the elements with indexes i and j.
+func (pq *MinMaxPriorityQueue[E]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+}
+
+// isMinLevel reports whether index i is on a min level, that is, at an even depth.
+func isMinLevel(i int) bool {
+	return (bits.Len(uint(i+1))-1)%2 == 0
+}
+
+// pushUp moves the element at index i towards the root until the min-max heap invariants hold.
+func (pq *MinMaxPriorityQueue[E]) pushUp(i int) {
+	if i == 0 {
+		return
+	}
+	p := (i - 1) / 2
+	if isMinLevel(i) {
+		if pq.less(p, i) {
+			pq.swap(i, p)
+			pq.pushUpLevel(p, false)
+		} else {
+			pq.pushUpLevel(i, true)
+		}
+	} else {
+		if pq.less(i, p) {
+			pq.swap(i, p)
+			pq.pushUpLevel(p, true)
+		} else {
+			pq.pushUpLevel(i, false)
+		}
+	}
+}
+
+// pushUpLevel moves the element at index i up through its grandparents, which are on the same kind of level.
+func (pq *MinMaxPriorityQueue[E]) pushUpLevel(i int, minLevel bool) {
+	for i > 2 {
+		g := ((i-1)/2 - 1) / 2
+		if minLevel && !pq.less(i, g) || !minLevel && !pq.less(g, i) {
+			return
+		}
+		pq.swap(i, g)
+		i = g
+	}
+}
+
+// pushDown moves the element at index i towards the leaves until the min-max heap invariants hold,
+// and returns the final index of that element.
+func (pq *MinMaxPriorityQueue[E]) pushDown(i int) int {
+	minLevel := isMinLevel(i)
+	n := len(pq.items)
+	pos := i
+	for {
+		// find the best of the children and grandchildren
+		best := -1
+		for _, c := range []int{2*i + 1, 2*i + 2, 4*i + 3, 4*i + 4, 4*i + 5, 4*i + 6} {
+			if c >= n {
+				continue
+			}
+			if best < 0 || minLevel && pq.less(c, best) || !minLevel && pq.less(best, c) {
+				best = c
+			}
+		}
+		if best < 0 {
+			return pos
+		}
+
+		if minLevel && !pq.less(best, i) || !minLevel && !pq.less(i, best) {
+			return pos
+		}
+		pq.swap(best, i)
+		if pos == i {
+			pos = best
+		}
+		if best <= 2*i+2 {
+			// a child is on the opposite kind of level, so the invariants hold
+			return pos
+		}
+
+		// a grandchild may now be out of order with its parent, which is on the opposite kind of level
+		p := (best - 1) / 2
+		if minLevel && pq.less(p, best) || !minLevel && pq.less(best, p) {
+			pq.swap(best, p)
+			if pos == best {
+				pos = p
+			}
+		}
+		i = best
+	}
+}
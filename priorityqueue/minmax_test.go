@@ -0,0 +1,48 @@
+package priorityqueue
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMinMaxPriorityQueue(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(4, 5))
+	pq := NewMinMax[int]()
+	var want []int
+	for range 1000 {
+		if len(want) > 0 && rnd.IntN(3) == 0 {
+			slices.Sort(want)
+			if rnd.IntN(2) == 0 {
+				if got := pq.PollFirst(); got != want[0] {
+					t.Fatalf("PollFirst() = %d, want %d", got, want[0])
+				}
+				want = want[1:]
+			} else {
+				if got := pq.PollLast(); got != want[len(want)-1] {
+					t.Fatalf("PollLast() = %d, want %d", got, want[len(want)-1])
+				}
+				want = want[:len(want)-1]
+			}
+			continue
+		}
+		item := rnd.IntN(100)
+		pq.Add(item)
+		want = append(want, item)
+	}
+	if pq.Size() != len(want) {
+		t.Errorf("Size() = %d, want %d", pq.Size(), len(want))
+	}
+}
+
+func TestMinMaxBounded(t *testing.T) {
+	pq := NewMinMax(WithMaxCapacity[int](3))
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		pq.Offer(v)
+	}
+	got := pq.ToArray()
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 3, 5}) {
+		t.Errorf("retained %v, want [1 3 5]", got)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !pqdebug
+
+package priorityqueue
+
+// debugInvariants enables checking the heap invariants after every mutation.
+const debugInvariants = false
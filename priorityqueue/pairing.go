@@ -0,0 +1,392 @@
+package priorityqueue
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// pairingHeap is an internal type that implements a pairing heap, the backend selected by WithPairingHeap.
+// Insertion takes O(1) time, and removing the minimum element takes O(log n) amortized time.
+type pairingHeap[E any] struct {
+	root       *pairingNode[E]
+	size       int
+	comparator util.Comparator[E]
+
+	// nextSeq is the insertion sequence number of the next element, which breaks ties when stable is set.
+	nextSeq uint64
+	stable  bool
+
+	// counts holds the number of occurrences of each element in lazy deletion mode, and is nil otherwise.
+	counts occurrenceCounts[E]
+}
+
+// pairingNode is a node of a pairing heap.
+type pairingNode[E any] struct {
+	item  E
+	seq   uint64
+	child *pairingNode[E]
+
+	// sibling is the next sibling of this node.
+	sibling *pairingNode[E]
+
+	// prev is the previous sibling of this node, or its parent if this node is the first child.
+	prev *pairingNode[E]
+}
+
+// Len returns the number of elements in the heap.
+func (ph *pairingHeap[E]) Len() int {
+	return ph.size
+}
+
+// all returns an iterator over the elements of the heap in depth-first order.
+// The heap must not be modified during the iteration.
+func (ph *pairingHeap[E]) all() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		ph.walk(func(n *pairingNode[E]) bool {
+			return yield(n.item)
+		})
+	}
+}
+
+// peek returns the minimum element of the non-empty heap.
+func (ph *pairingHeap[E]) peek() E {
+	return ph.root.item
+}
+
+// push inserts the element in O(1) time.
+func (ph *pairingHeap[E]) push(item E) {
+	ph.counts.add(item, 1)
+	ph.insert(item)
+	debugCheck(ph)
+}
+
+// pushAll inserts all of the given elements, each in O(1) time.
+func (ph *pairingHeap[E]) pushAll(items []E) {
+	for _, item := range items {
+		ph.counts.add(item, 1)
+		ph.insert(item)
+	}
+	debugCheck(ph)
+}
+
+// meldHeap moves all of the nodes of the other heap, which must use the same comparator, into this heap
+// and leaves the other heap empty. The roots are melded in O(1) time, so the nodes of the other heap are only visited
+// when stable is set, to renumber them after the nodes of this heap.
+func (ph *pairingHeap[E]) meldHeap(other *pairingHeap[E]) {
+	if ph.stable {
+		offset := ph.nextSeq
+		other.walk(func(n *pairingNode[E]) bool {
+			n.seq += offset
+			return true
+		})
+		ph.nextSeq += other.nextSeq
+	}
+	for k, n := range other.counts {
+		ph.counts[k] += n
+	}
+	ph.root = ph.meld(ph.root, other.root)
+	ph.size += other.size
+
+	other.root = nil
+	other.size = 0
+	clear(other.counts)
+	debugCheck(ph)
+}
+
+// pop removes and returns the minimum element of the non-empty heap.
+func (ph *pairingHeap[E]) pop() E {
+	item := ph.root.item
+	ph.root = ph.mergePairs(ph.root.child)
+	ph.size--
+	ph.counts.add(item, -1)
+	debugCheck(ph)
+	return item
+}
+
+// replaceRoot replaces the minimum element of the non-empty heap with the given element.
+func (ph *pairingHeap[E]) replaceRoot(item E) {
+	ph.pop()
+	ph.push(item)
+}
+
+// removeFunc removes the first element, in depth-first order, that satisfies match.
+func (ph *pairingHeap[E]) removeFunc(match func(E) bool) (E, bool) {
+	var found *pairingNode[E]
+	ph.walk(func(n *pairingNode[E]) bool {
+		if match(n.item) {
+			found = n
+			return false
+		}
+		return true
+	})
+	if found == nil {
+		var zero E
+		return zero, false
+	}
+	if found == ph.root {
+		return ph.pop(), true
+	}
+
+	ph.cut(found)
+	ph.root = ph.meld(ph.root, ph.mergePairs(found.child))
+	ph.size--
+	ph.counts.add(found.item, -1)
+	debugCheck(ph)
+	return found.item, true
+}
+
+// removeIf removes all of the elements that satisfy the filter and melds the remaining nodes into a new heap.
+func (ph *pairingHeap[E]) removeIf(filter func(E) bool) bool {
+	removed := ph.rebuild(filter)
+	debugCheck(ph)
+	return removed
+}
+
+// setItems replaces the elements of the heap with the given ones.
+func (ph *pairingHeap[E]) setItems(items []E) {
+	ph.root = nil
+	ph.size = 0
+	ph.counts.reset(slices.Values(items))
+	for _, item := range items {
+		ph.insert(item)
+	}
+	debugCheck(ph)
+}
+
+// reorder replaces the comparator and melds the nodes into a new heap.
+func (ph *pairingHeap[E]) reorder(comparator util.Comparator[E]) {
+	ph.comparator = comparator
+	ph.rebuild(func(E) bool { return false })
+	debugCheck(ph)
+}
+
+// smallest returns the k smallest elements in priority order.
+// As the children of a node all sort no earlier than the node, only the children of the nodes already returned are candidates.
+func (ph *pairingHeap[E]) smallest(k int) []E {
+	// frontier is a heap of the candidates for the next element
+	frontier := &internalHeap[*pairingNode[E]]{
+		items: make([]*pairingNode[E], 0, k),
+		comparator: func(a, b *pairingNode[E]) int {
+			if ph.less(a, b) {
+				return -1
+			}
+			if ph.less(b, a) {
+				return 1
+			}
+			return 0
+		},
+		arity: 2,
+	}
+	frontier.push(ph.root)
+
+	items := make([]E, 0, k)
+	for len(items) < k {
+		n := frontier.pop()
+		items = append(items, n.item)
+		for c := n.child; c != nil; c = c.sibling {
+			frontier.push(c)
+		}
+	}
+	return items
+}
+
+// occurrences returns the number of occurrences of the element with the key k.
+func (ph *pairingHeap[E]) occurrences(k any) int {
+	return ph.counts[k]
+}
+
+// check verifies that every node sorts no earlier than its parent, that the links between the nodes are consistent,
+// and that the size and the occurrence counts match the elements.
+func (ph *pairingHeap[E]) check() error {
+	if ph.root != nil && ph.root.prev != nil {
+		return fmt.Errorf("priorityqueue: root %v has a parent or sibling", ph.root.item)
+	}
+
+	var err error
+	size := 0
+	ph.walk(func(n *pairingNode[E]) bool {
+		size++
+		prev := n
+		for c := n.child; c != nil; c = c.sibling {
+			if c.prev != prev {
+				err = fmt.Errorf("priorityqueue: inconsistent links at node %v", c.item)
+				return false
+			}
+			if ph.less(c, n) {
+				err = fmt.Errorf("priorityqueue: heap property violated between %v and its parent %v", c.item, n.item)
+				return false
+			}
+			prev = c
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if size != ph.size {
+		return fmt.Errorf("priorityqueue: %d nodes for size %d", size, ph.size)
+	}
+	return ph.counts.check(ph.all())
+}
+
+// clone returns a deep copy of the heap.
+func (ph *pairingHeap[E]) clone() backend[E] {
+	clone := &pairingHeap[E]{
+		size:       ph.size,
+		comparator: ph.comparator,
+		nextSeq:    ph.nextSeq,
+		stable:     ph.stable,
+		counts:     maps.Clone(ph.counts),
+	}
+	if ph.root == nil {
+		return clone
+	}
+
+	// each node of stack is paired with its copy, whose children are still to be copied
+	type pair struct{ src, dst *pairingNode[E] }
+	clone.root = &pairingNode[E]{item: ph.root.item, seq: ph.root.seq}
+	stack := []pair{{ph.root, clone.root}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		prev := p.dst
+		for c := p.src.child; c != nil; c = c.sibling {
+			d := &pairingNode[E]{item: c.item, seq: c.seq, prev: prev}
+			if prev == p.dst {
+				p.dst.child = d
+			} else {
+				prev.sibling = d
+			}
+			prev = d
+			stack = append(stack, pair{c, d})
+		}
+	}
+	return clone
+}
+
+// insert melds a new node holding the element into the heap, without counting its occurrence.
+func (ph *pairingHeap[E]) insert(item E) {
+	n := &pairingNode[E]{item: item, seq: ph.nextSeq}
+	ph.nextSeq++
+	ph.root = ph.meld(ph.root, n)
+	ph.size++
+}
+
+// rebuild detaches all of the nodes, and melds those whose element does not satisfy the filter into a new heap.
+// It reports whether any node was dropped.
+func (ph *pairingHeap[E]) rebuild(filter func(E) bool) bool {
+	nodes := make([]*pairingNode[E], 0, ph.size)
+	ph.walk(func(n *pairingNode[E]) bool {
+		nodes = append(nodes, n)
+		return true
+	})
+
+	ph.root = nil
+	ph.size = 0
+	for _, n := range nodes {
+		if filter(n.item) {
+			ph.counts.add(n.item, -1)
+			continue
+		}
+		n.child, n.sibling, n.prev = nil, nil, nil
+		ph.root = ph.meld(ph.root, n)
+		ph.size++
+	}
+	return ph.size < len(nodes)
+}
+
+// less reports whether the element of node a should sort before the element of node b.
+func (ph *pairingHeap[E]) less(a, b *pairingNode[E]) bool {
+	c := ph.comparator(a.item, b.item)
+	if c == 0 && ph.stable {
+		return a.seq < b.seq
+	}
+	return c < 0
+}
+
+// meld links two heaps and returns the root of the result.
+// The root that sorts after the other becomes the first child of the other.
+func (ph *pairingHeap[E]) meld(a, b *pairingNode[E]) *pairingNode[E] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if ph.less(b, a) {
+		a, b = b, a
+	}
+
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs melds a list of siblings into a single heap using the standard two-pass method.
+func (ph *pairingHeap[E]) mergePairs(first *pairingNode[E]) *pairingNode[E] {
+	if first == nil {
+		return nil
+	}
+
+	// first pass: meld pairs from left to right
+	var pairs []*pairingNode[E]
+	for first != nil {
+		a := first
+		b := a.sibling
+		if b == nil {
+			a.prev = nil
+			pairs = append(pairs, a)
+			break
+		}
+		first = b.sibling
+		a.prev, a.sibling = nil, nil
+		b.prev, b.sibling = nil, nil
+		pairs = append(pairs, ph.meld(a, b))
+	}
+
+	// second pass: meld the results from right to left
+	root := pairs[len(pairs)-1]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		root = ph.meld(pairs[i], root)
+	}
+	return root
+}
+
+// cut detaches the non-root node n, together with its subtree, from the heap.
+func (ph *pairingHeap[E]) cut(n *pairingNode[E]) {
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev, n.sibling = nil, nil
+}
+
+// walk calls visit for each node of the heap in depth-first order, until visit returns false.
+func (ph *pairingHeap[E]) walk(visit func(n *pairingNode[E]) bool) {
+	if ph.root == nil {
+		return
+	}
+	stack := []*pairingNode[E]{ph.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !visit(n) {
+			return
+		}
+		for c := n.child; c != nil; c = c.sibling {
+			stack = append(stack, c)
+		}
+	}
+}
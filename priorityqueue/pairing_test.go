@@ -0,0 +1,248 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestPairingHeapOrder(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = r.IntN(100)
+	}
+
+	pq := New(WithPairingHeap[int](), WithInitialItems(items[:500]...))
+	pq.AddAll(items[500:])
+	if err := pq.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := slices.Sorted(slices.Values(items))
+	if got := pq.NSmallest(10); !slices.Equal(got, want[:10]) {
+		t.Errorf("NSmallest(10) = %v, want %v", got, want[:10])
+	}
+	var polled []int
+	for !pq.IsEmpty() {
+		polled = append(polled, pq.Poll())
+	}
+	if !slices.Equal(polled, want) {
+		t.Errorf("polled %v, want %v", polled, want)
+	}
+}
+
+func TestPairingHeapStable(t *testing.T) {
+	pq := New(WithComparator(byPriority), WithStableOrdering[task](), WithPairingHeap[task]())
+	pq.AddAll([]task{{2, "a"}, {1, "b"}, {2, "c"}, {1, "d"}, {2, "e"}})
+
+	var names string
+	for !pq.IsEmpty() {
+		names += pq.Poll().name
+	}
+	if names != "bdace" {
+		t.Errorf("polled %s, want bdace", names)
+	}
+}
+
+func TestPairingHeapRemove(t *testing.T) {
+	pq := New(WithPairingHeap[int](), WithInitialItems(5, 3, 8, 1, 9, 3))
+	if !pq.Remove(3) || !pq.Remove(1) {
+		t.Fatal("Remove() = false, want true")
+	}
+	if pq.Remove(7) {
+		t.Error("Remove(7) = true, want false")
+	}
+	pq.RemoveIf(func(v int) bool { return v > 8 })
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{3, 5, 8}) {
+		t.Errorf("ToSortedArray() = %v, want [3 5 8]", got)
+	}
+	if err := pq.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPairingHeapMerge(t *testing.T) {
+	pq := New(WithPairingHeap[int](), WithInitialItems(5, 1, 3))
+	other := New(WithInitialItems(4, 2))
+	if err := pq.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("ToSortedArray() = %v, want [1 2 3 4 5]", got)
+	}
+	if !other.IsEmpty() {
+		t.Errorf("other queue holds %v after Merge, want it empty", other)
+	}
+}
+
+// TestPairingHeapMergeMelds checks that merging two pairing heaps melds their roots with a single comparison,
+// instead of visiting the nodes of the other queue.
+func TestPairingHeapMergeMelds(t *testing.T) {
+	var compares int
+	counting := func(a, b int) int {
+		compares++
+		return cmp.Compare(a, b)
+	}
+	pq := New(WithComparator(counting), WithPairingHeap[int](), WithInitialItems(randomInts(1000)...))
+	other := New(WithComparator(counting), WithPairingHeap[int](), WithInitialItems(randomInts(2000)...))
+
+	compares = 0
+	if err := pq.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	if compares > 1 && !debugInvariants {
+		t.Errorf("Merge made %d comparisons, want 1", compares)
+	}
+	if pq.Size() != 3000 || !other.IsEmpty() {
+		t.Errorf("Size() = %d and other Size() = %d after Merge, want 3000 and 0", pq.Size(), other.Size())
+	}
+	if err := pq.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+	want := slices.Sorted(slices.Values(append(randomInts(1000), randomInts(2000)...)))
+	if got := pq.ToSortedArray(); !slices.Equal(got, want) {
+		t.Error("ToSortedArray() does not hold the elements of both queues")
+	}
+}
+
+func TestPairingHeapMergeStable(t *testing.T) {
+	pq := New(WithComparator(byPriority), WithStableOrdering[task](), WithPairingHeap[task]())
+	pq.AddAll([]task{{1, "a"}, {2, "b"}, {1, "c"}})
+	other := New(WithComparator(byPriority), WithStableOrdering[task](), WithPairingHeap[task]())
+	other.AddAll([]task{{2, "d"}, {1, "e"}, {1, "f"}})
+	other.Poll()
+
+	if err := pq.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	pq.Add(task{1, "g"})
+	var names string
+	for !pq.IsEmpty() {
+		names += pq.Poll().name
+	}
+	if names != "acfgbd" {
+		t.Errorf("polled %s, want acfgbd", names)
+	}
+}
+
+func TestPairingHeapMergeLazyDeletion(t *testing.T) {
+	pq := New(WithPairingHeap[int](), WithLazyDeletion[int](), WithInitialItems(1, 2, 3))
+	other := New(WithPairingHeap[int](), WithLazyDeletion[int](), WithInitialItems(2, 4, 5))
+	other.Remove(4)
+	pq.Remove(2)
+	if err := pq.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	if !pq.Remove(2) || pq.Remove(4) {
+		t.Error("Remove did not match the merged occurrences")
+	}
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{1, 3, 5}) {
+		t.Errorf("ToSortedArray() = %v, want [1 3 5]", got)
+	}
+	if err := pq.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPairingHeapMergeMaxCapacity(t *testing.T) {
+	pq := New(WithPairingHeap[int](), WithMaxCapacity[int](4), WithInitialItems(1, 2))
+	other := New(WithPairingHeap[int](), WithInitialItems(3, 4, 5))
+	if err := pq.Merge(other); err != ErrQueueFull {
+		t.Fatalf("Merge() = %v, want ErrQueueFull", err)
+	}
+	if pq.Size() != 2 || other.Size() != 3 {
+		t.Errorf("sizes %d and %d after a rejected Merge, want 2 and 3", pq.Size(), other.Size())
+	}
+}
+
+func TestPairingHeapLazyDeletion(t *testing.T) {
+	pq := New(WithPairingHeap[int](), WithLazyDeletion[int](), WithInitialItems(5, 3, 5, 1))
+	pq.Remove(1)
+	pq.Remove(5)
+	if pq.Remove(7) {
+		t.Error("Remove(7) = true, want false")
+	}
+	if got := pq.Peek(); got != 3 {
+		t.Errorf("Peek() = %d, want 3", got)
+	}
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{3, 5}) {
+		t.Errorf("ToSortedArray() = %v, want [3 5]", got)
+	}
+	if err := pq.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPairingHeapIteratorRemove(t *testing.T) {
+	pq := New(WithPairingHeap[int](), WithInitialItems(1, 2, 3, 4, 5, 6))
+	it := pq.Iterator()
+	var seen []int
+	for it.HasNext() {
+		v := it.Next()
+		seen = append(seen, v)
+		if v%2 == 0 {
+			it.Remove()
+		}
+	}
+	slices.Sort(seen)
+	if !slices.Equal(seen, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("iterated %v, want [1 2 3 4 5 6]", seen)
+	}
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{1, 3, 5}) {
+		t.Errorf("ToSortedArray() = %v, want [1 3 5]", got)
+	}
+}
+
+func TestPairingHeapClone(t *testing.T) {
+	pq := New(WithPairingHeap[int](), WithInitialItems(4, 2, 6))
+	clone := pq.Clone()
+	clone.Poll()
+	clone.Add(1)
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{2, 4, 6}) {
+		t.Errorf("original ToSortedArray() = %v, want [2 4 6]", got)
+	}
+	if got := clone.ToSortedArray(); !slices.Equal(got, []int{1, 4, 6}) {
+		t.Errorf("clone ToSortedArray() = %v, want [1 4 6]", got)
+	}
+	if err := clone.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPairingHeapSetComparator(t *testing.T) {
+	pq := New(WithPairingHeap[int](), WithInitialItems(1, 5, 3))
+	pq.SetComparator(func(a, b int) int { return b - a })
+	if got := pq.Poll(); got != 5 {
+		t.Errorf("Poll() = %d, want 5", got)
+	}
+	if err := pq.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPairingHeapJSON(t *testing.T) {
+	pq := New(WithPairingHeap[int](), WithInitialItems(3, 1, 2))
+	data, err := json.Marshal(pq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := New(WithPairingHeap[int]())
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got := decoded.ToSortedArray(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("ToSortedArray() = %v, want [1 2 3]", got)
+	}
+}
+
+func TestPairingHeapInterfacePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("HeapInterface did not panic for a pairing heap")
+		}
+	}()
+	New(WithPairingHeap[int]()).HeapInterface()
+}
@@ -1,15 +1,129 @@
 package priorityqueue
 
 import (
-	"container/heap"
+	"cmp"
+	"errors"
+	"fmt"
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"iter"
+	"maps"
+	"math"
 	"reflect"
+	"slices"
+	"strings"
 	"github.com/nsce9806q/javastyle-collection/util"
 )
 
+// ErrQueueFull is returned when an element is inserted into a queue that already holds its maximum number of elements.
+var ErrQueueFull = errors.New("priorityqueue: queue is full")
+
+// ErrNoSuchElement is returned when an element is retrieved from an empty queue.
+var ErrNoSuchElement = errors.New("priorityqueue: no such element")
+
 // PriorityQueue is a priority queue data structure.
 type PriorityQueue[E any] struct {
-	heap   *internalHeap[E]
-	equals util.Equals[E]
+	heap       backend[E]
+	comparator util.Comparator[E]
+	equals     util.Equals[E]
+
+	// equalsByDefault is set when equals was not provided and is derived from the type or the comparator.
+	equalsByDefault bool
+
+	// deepEquals makes the default equality fall back to util.DeepEquals for types that cannot be compared with ==.
+	deepEquals bool
+
+	formatter   func(E) string
+	maxCapacity int
+	reversed    bool
+
+	// topK is the number of elements retained in top-K mode, or 0 if the mode is off.
+	topK int
+
+	observers []Observer[E]
+
+	// deleted counts the elements removed in lazy deletion mode that are still in the heap, keyed by the elements themselves,
+	// or is nil if the mode is off.
+	deleted map[any]int
+
+	// deletedCount is the total of the counts in deleted.
+	deletedCount int
+
+	// stable breaks comparator ties by insertion order.
+	stable bool
+
+	// initialItems holds the elements given by WithInitialItems until the queue is built.
+	initialItems []E
+
+	// capacity, arity, shrinkThreshold and pairing select the heap that New builds.
+	capacity        int
+	arity           int
+	shrinkThreshold float64
+	pairing         bool
+}
+
+// backend is the data structure that holds and orders the elements of a PriorityQueue.
+// The default is the slice-backed d-ary heap of internalHeap, and WithPairingHeap selects the pairing heap of pairingHeap.
+type backend[E any] interface {
+	// Len returns the number of elements.
+	Len() int
+
+	// all returns an iterator over the elements, in no particular order.
+	all() iter.Seq[E]
+
+	// peek returns the minimum element of the non-empty heap.
+	peek() E
+
+	// push inserts the element.
+	push(item E)
+
+	// pushAll inserts all of the given elements.
+	pushAll(items []E)
+
+	// pop removes and returns the minimum element of the non-empty heap.
+	pop() E
+
+	// replaceRoot replaces the minimum element of the non-empty heap with the given element.
+	replaceRoot(item E)
+
+	// removeFunc removes the first element that satisfies match, and reports whether there was one.
+	removeFunc(match func(E) bool) (E, bool)
+
+	// removeIf removes all of the elements that satisfy the filter, and reports whether any was removed.
+	removeIf(filter func(E) bool) bool
+
+	// setItems replaces the elements with the given ones, which the heap takes ownership of.
+	setItems(items []E)
+
+	// reorder replaces the comparator and restores the heap order.
+	reorder(comparator util.Comparator[E])
+
+	// smallest returns the k smallest elements in priority order without removing them, where k is at most Len.
+	smallest(k int) []E
+
+	// occurrences returns the number of occurrences of the element with the key k, which are counted in lazy deletion mode.
+	occurrences(k any) int
+
+	// check verifies the invariants of the heap.
+	check() error
+
+	// clone returns a copy of the heap that shares no mutable state with it.
+	clone() backend[E]
+}
+
+// PriorityQueue implements the Queue interface.
+var _ collection.Queue[int] = (*PriorityQueue[int])(nil)
+
+// Observer is a set of callbacks that are notified of the activity of a PriorityQueue.
+// Any of the callbacks may be nil. The callbacks are called synchronously and must not modify the queue.
+type Observer[E any] struct {
+	// OnOffer is called with each element inserted into the queue.
+	OnOffer func(item E)
+
+	// OnPoll is called with each element retrieved and removed from the head of the queue.
+	OnPoll func(item E)
+
+	// OnRemove is called with each element removed from the queue other than by polling its head.
+	OnRemove func(item E)
 }
 
 // Option is a function type that sets the PriorityQueue.
@@ -18,41 +132,272 @@ type Option[E any] func(*PriorityQueue[E])
 // WithCapacity is an option that sets the initial capacity.
 func WithCapacity[E any](initialCapacity int) Option[E] {
 	return func(pq *PriorityQueue[E]) {
-		pq.heap.items = make([]E, 0, initialCapacity)
+		pq.capacity = initialCapacity
+	}
+}
+
+// WithMaxCapacity is an option that sets the maximum number of elements the queue can hold.
+// Offer returns false and Add panics once the queue holds maxCapacity elements.
+func WithMaxCapacity[E any](maxCapacity int) Option[E] {
+	return func(pq *PriorityQueue[E]) {
+		pq.maxCapacity = maxCapacity
+	}
+}
+
+// WithTopK is an option that turns the queue into a bounded collector of the k greatest elements according to its comparator.
+// Once the queue holds k elements, offering an element that ranks after the head evicts the head, which is the smallest
+// retained element, and offering any other element discards it. Add and Offer always succeed in this mode.
+// Combine it with WithReverseOrder to collect the k smallest elements instead.
+func WithTopK[E any](k int) Option[E] {
+	if k < 1 {
+		panic("Top-K size must be at least 1")
+	}
+	return func(pq *PriorityQueue[E]) {
+		pq.topK = k
+	}
+}
+
+// WithArity is an option that sets the number of children of each node of the heap.
+// The default is a binary heap, which HeapInterface requires. A wider heap, such as a 4-ary heap, is shallower and
+// makes insertions cheaper and more cache friendly, at the cost of more comparisons per removal;
+// BenchmarkArity measures the trade-off.
+func WithArity[E any](d int) Option[E] {
+	if d < 2 {
+		panic("Arity must be at least 2")
+	}
+	return func(pq *PriorityQueue[E]) {
+		pq.arity = d
+	}
+}
+
+// WithShrinkThreshold is an option that shrinks the backing slice automatically
+// when the number of elements drops below the given fraction of its capacity.
+// The fraction must be greater than 0 and at most 0.5, so that a shrunk slice is not immediately shrunk again.
+func WithShrinkThreshold[E any](fraction float64) Option[E] {
+	if fraction <= 0 || fraction > 0.5 {
+		panic("Shrink threshold must be greater than 0 and at most 0.5")
+	}
+	return func(pq *PriorityQueue[E]) {
+		pq.shrinkThreshold = fraction
+	}
+}
+
+// WithInitialItems is an option that populates the queue with the given elements.
+// The elements are copied and the heap is built once in linear time, after all other options have been applied.
+func WithInitialItems[E any](items ...E) Option[E] {
+	return func(pq *PriorityQueue[E]) {
+		pq.initialItems = append(pq.initialItems, items...)
+	}
+}
+
+// WithObserver is an option that registers callbacks notified when elements are offered, polled or removed.
+// It can be given more than once to register several observers.
+func WithObserver[E any](observer Observer[E]) Option[E] {
+	return func(pq *PriorityQueue[E]) {
+		pq.observers = append(pq.observers, observer)
+	}
+}
+
+// WithLazyDeletion is an option that makes Remove mark the element as deleted in O(1) time, instead of searching for it.
+// Deleted elements are skipped when they reach the head of the queue, and compacted before any operation that visits all elements.
+// The queue counts the occurrences of its elements to tell whether Remove is given an element that is in the queue,
+// so in this mode Remove matches elements with == instead of the equality function, and the element type must be comparable.
+// New panics if it is not, and Remove panics if it is an interface type holding a value that is not comparable.
+func WithLazyDeletion[E any]() Option[E] {
+	return func(pq *PriorityQueue[E]) {
+		pq.deleted = make(map[any]int)
 	}
 }
 
 // WithComparator is an option that sets the custom comparator.
 func WithComparator[E any](comparator util.Comparator[E]) Option[E] {
 	return func(pq *PriorityQueue[E]) {
-		pq.heap.comparator = comparator
+		pq.comparator = comparator
+	}
+}
+
+// WithStableOrdering is an option that breaks comparator ties by insertion order,
+// so that elements with equal priority are polled in FIFO order.
+func WithStableOrdering[E any]() Option[E] {
+	return func(pq *PriorityQueue[E]) {
+		pq.stable = true
+	}
+}
+
+// WithPairingHeap is an option that stores the elements in a pairing heap instead of the default d-ary heap.
+// A pairing heap inserts an element in O(1) time and polls in O(log n) amortized time, so it suits workloads that insert,
+// or merge queues, much more often than they poll. It has no backing slice: WithCapacity, WithArity and WithShrinkThreshold
+// have no effect, Capacity returns the number of elements, and HeapInterface panics.
+func WithPairingHeap[E any]() Option[E] {
+	return func(pq *PriorityQueue[E]) {
+		pq.pairing = true
+	}
+}
+
+// WithReverseOrder is an option that reverses the ordering of the comparator, turning the queue into a max-heap.
+// It applies to the comparator set by WithComparator regardless of the order of the options.
+func WithReverseOrder[E any]() Option[E] {
+	return func(pq *PriorityQueue[E]) {
+		pq.reversed = true
 	}
 }
 
 // WithEquals is an option that sets the custom equality comparison function.
+// Without it, comparable types are compared with ==, and other types are considered equal when the comparator returns 0.
 func WithEquals[E any](equals util.Equals[E]) Option[E] {
 	return func(pq *PriorityQueue[E]) {
 		pq.equals = equals
 	}
 }
 
+// WithDeepEqualsFallback is an option that makes the default equality, used when WithEquals is not given,
+// compare elements with util.DeepEquals if the element type cannot be compared with ==, such as slices and maps,
+// instead of by the comparator.
+func WithDeepEqualsFallback[E any]() Option[E] {
+	return func(pq *PriorityQueue[E]) {
+		pq.deepEquals = true
+	}
+}
+
+// WithFormatter is an option that sets the function used to format each element in String.
+func WithFormatter[E any](formatter func(E) string) Option[E] {
+	return func(pq *PriorityQueue[E]) {
+		pq.formatter = formatter
+	}
+}
+
 // New creates a new PriorityQueue with the given options.
+// Without WithComparator, the elements are ordered by util.DefaultComparator, which polls NaN before any other float64 value.
 func New[E any](opts ...Option[E]) *PriorityQueue[E] {
-	pq := &PriorityQueue[E]{
-		heap: &internalHeap[E]{
-			items:      make([]E, 0, 11),
-			comparator: util.DefaultComparator[E](),
-		},
+	pq := resolveOptions(opts)
+	var counts occurrenceCounts[E]
+	if pq.deleted != nil {
+		if !reflect.TypeFor[E]().Comparable() {
+			panic("Lazy deletion requires a comparable element type")
+		}
+		counts = make(occurrenceCounts[E])
+	}
+	if pq.pairing {
+		pq.heap = &pairingHeap[E]{
+			comparator: pq.comparator,
+			stable:     pq.stable,
+			counts:     counts,
+		}
+	} else {
+		pq.heap = &internalHeap[E]{
+			items:           make([]E, 0, pq.capacity),
+			comparator:      pq.comparator,
+			arity:           pq.arity,
+			shrinkThreshold: pq.shrinkThreshold,
+			stable:          pq.stable,
+			counts:          counts,
+		}
+	}
+
+	if pq.topK > 0 {
+		items := pq.initialItems
+		pq.initialItems = nil
+		for _, item := range items {
+			pq.retainTopK(item)
+		}
+	}
+	if len(pq.initialItems) > 0 {
+		if pq.maxCapacity > 0 && len(pq.initialItems) > pq.maxCapacity {
+			panic("Queue is full")
+		}
+		pq.heap.pushAll(pq.initialItems)
+		pq.initialItems = nil
 	}
+	return pq
+}
 
+// resolveOptions applies the given options to a PriorityQueue without a heap,
+// and resolves the comparator and the equality function they select.
+// New builds the heap from the result, and the other queue types of this package take the options they use from it.
+func resolveOptions[E any](opts []Option[E]) *PriorityQueue[E] {
+	pq := &PriorityQueue[E]{
+		comparator: util.DefaultComparator[E](),
+		capacity:   11,
+		arity:      2,
+	}
 	for _, opt := range opts {
 		opt(pq)
 	}
+	if pq.reversed {
+		pq.comparator = util.ReverseOrder(pq.comparator)
+	}
+	if pq.equals == nil {
+		pq.equals = defaultEquals(pq.compare, pq.deepEquals)
+		pq.equalsByDefault = true
+	}
+	return pq
+}
+
+// ComparatorOf returns the comparator that a queue created by New with the given options would use, without creating one.
+// It lets queue implementations outside this package, such as the concurrent package, accept the options of this package.
+func ComparatorOf[E any](opts ...Option[E]) util.Comparator[E] {
+	return resolveOptions(opts).comparator
+}
+
+// NewOrdered creates a new PriorityQueue of an ordered type, such as int64, uint or float32,
+// ordered by the natural ordering of the type, with the given options.
+// Unlike the default comparator, the ordering is checked at compile time, and elements are compared with == directly.
+// NaN is ordered before any other floating-point value, as with util.NaNFirst.
+func NewOrdered[E cmp.Ordered](opts ...Option[E]) *PriorityQueue[E] {
+	return New(append([]Option[E]{WithComparator[E](cmp.Compare[E]), WithEquals(util.EqualsOf[E]())}, opts...)...)
+}
+
+// NewMaxHeap creates a new PriorityQueue that polls the greatest element first.
+// It is equivalent to calling New with WithReverseOrder.
+func NewMaxHeap[E any](opts ...Option[E]) *PriorityQueue[E] {
+	return New(append(opts, WithReverseOrder[E]())...)
+}
 
-	heap.Init(pq.heap)
+// NewFromSlice creates a new PriorityQueue containing the elements in the given slice.
+// The slice is copied and the heap is built in linear time.
+func NewFromSlice[E any](items []E, opts ...Option[E]) *PriorityQueue[E] {
+	pq := New(opts...)
+	pq.AddAll(items)
 	return pq
 }
 
+// NewFromQueue creates a new PriorityQueue containing the elements in the given queue.
+// The new queue uses the same ordering and equality functions as the given queue.
+func NewFromQueue[E any](other *PriorityQueue[E]) *PriorityQueue[E] {
+	return other.Clone()
+}
+
+// Returns a copy of this queue.
+// The heap and the list of observers are copied, while the comparator, equals functions and observer callbacks are shared.
+// Object clone()
+func (pq *PriorityQueue[E]) Clone() *PriorityQueue[E] {
+	pq.purge()
+	clone := &PriorityQueue[E]{
+		heap:            pq.heap.clone(),
+		comparator:      pq.comparator,
+		equals:          pq.equals,
+		equalsByDefault: pq.equalsByDefault,
+		deepEquals:      pq.deepEquals,
+		formatter:       pq.formatter,
+		maxCapacity:     pq.maxCapacity,
+		topK:            pq.topK,
+		observers:       slices.Clone(pq.observers),
+		stable:          pq.stable,
+		capacity:        pq.capacity,
+		arity:           pq.arity,
+		shrinkThreshold: pq.shrinkThreshold,
+		pairing:         pq.pairing,
+	}
+	if pq.deleted != nil {
+		clone.deleted = make(map[any]int)
+	}
+	if clone.equalsByDefault {
+		// the default equality may depend on the comparator, so bind it to the clone
+		clone.equals = defaultEquals(clone.compare, clone.deepEquals)
+	}
+	return clone
+}
+
 // Inserts the specified element into this priority queue.
 // boolean add(E e)
 func (pq *PriorityQueue[E]) Add(item E) bool {
@@ -64,158 +409,925 @@ func (pq *PriorityQueue[E]) Add(item E) bool {
 
 // Inserts the specified element into this priority queue.
 // boolean offer(E e)
-func (pq *PriorityQueue[E]) Offer(item E) (success bool) {
-	defer func() {
-		if r := recover(); r != nil {
-			success = false
+func (pq *PriorityQueue[E]) Offer(item E) bool {
+	return pq.OfferE(item) == nil
+}
+
+// OfferE inserts the specified element into this priority queue.
+// It returns ErrQueueFull if the queue already holds its maximum number of elements.
+func (pq *PriorityQueue[E]) OfferE(item E) error {
+	if pq.topK > 0 {
+		evicted, hasEvicted, retained := pq.retainTopK(item)
+		if hasEvicted {
+			pq.notifyRemove(evicted)
 		}
-	}()
-	heap.Push(pq.heap, item)
-	return true
+		if retained {
+			pq.notifyOffer(item)
+		}
+		return nil
+	}
+	if pq.maxCapacity > 0 && pq.Size() >= pq.maxCapacity {
+		return ErrQueueFull
+	}
+	pq.heap.push(item)
+	pq.notifyOffer(item)
+	return nil
+}
+
+// Adds all of the elements in the specified slice to this queue.
+// The elements are inserted as a batch, as with OfferAll.
+// boolean addAll(Collection<? extends E> c)
+func (pq *PriorityQueue[E]) AddAll(items []E) bool {
+	if err := pq.AddAllE(items); err != nil {
+		panic("Queue is full")
+	}
+	return len(items) > 0
+}
+
+// AddAllE adds all of the elements in the specified slice to this queue.
+// It returns ErrQueueFull, and adds none of the elements, if they do not all fit within the maximum capacity.
+func (pq *PriorityQueue[E]) AddAllE(items []E) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if pq.topK > 0 {
+		for _, item := range items {
+			pq.OfferE(item)
+		}
+		return nil
+	}
+	if pq.maxCapacity > 0 && pq.Size()+len(items) > pq.maxCapacity {
+		return ErrQueueFull
+	}
+	pq.heap.pushAll(items)
+	for _, item := range items {
+		pq.notifyOffer(item)
+	}
+	return nil
+}
+
+// OfferAll inserts all of the elements in the specified slice into this queue.
+// The backing slice is grown once, and the heap order is restored either by sifting up each new element
+// or by rebuilding the whole heap, whichever needs fewer comparisons for the size of the batch.
+// It returns false, and inserts none of the elements, if they do not all fit within the maximum capacity.
+func (pq *PriorityQueue[E]) OfferAll(items []E) bool {
+	return pq.AddAllE(items) == nil
+}
+
+// Adds all of the elements in the specified queue to this queue.
+// boolean addAll(Collection<? extends E> c)
+func (pq *PriorityQueue[E]) AddAllFrom(other *PriorityQueue[E]) bool {
+	if other == pq {
+		panic("Cannot add a queue to itself")
+	}
+	return pq.AddAll(other.orderedElements())
+}
+
+// Merge moves all of the elements of the other queue into this queue and leaves the other queue empty.
+// The elements are concatenated and the heap is rebuilt once, in O(n) time.
+// If both queues use WithPairingHeap, have the same stable ordering and lazy deletion settings, and have no observers,
+// the heap of the other queue is instead melded into this one in O(1) amortized time, or O(m) with WithStableOrdering,
+// where m is the size of the other queue. The other queue must then order its elements with the same comparator as this one.
+// Otherwise, the elements of the other queue are inserted in O(1) time each with WithPairingHeap.
+// It returns ErrQueueFull, and leaves both queues unchanged, if the elements do not all fit within the maximum capacity of this queue.
+func (pq *PriorityQueue[E]) Merge(other *PriorityQueue[E]) error {
+	if other == pq {
+		panic("Cannot merge a queue with itself")
+	}
+	if ph, oh, ok := pq.meldable(other); ok {
+		ph.meldHeap(oh)
+		return nil
+	}
+	if err := pq.AddAllE(other.orderedElements()); err != nil {
+		return err
+	}
+	other.Clear()
+	return nil
 }
 
 // Removes all of the elements from this priority queue.
 // void clear()
 func (pq *PriorityQueue[E]) Clear() {
-	pq.heap.items = []E{}
-	heap.Init(pq.heap)
+	items := pq.elements()
+	pq.heap.setItems([]E{})
+	for _, item := range items {
+		pq.notifyRemove(item)
+	}
 }
 
 // Returns the comparator used to order the elements in this queue, or defaultComparator if the queue uses the natural ordering of its elements.
 // Comparator<? super E> comparator()
 func (pq *PriorityQueue[E]) Comparator() util.Comparator[E] {
-	return pq.heap.comparator
+	return pq.comparator
+}
+
+// SetComparator replaces the comparator used to order the elements in this queue and rebuilds the heap in O(n) time.
+// The comparator is used as is, even if the queue was created with WithReverseOrder.
+func (pq *PriorityQueue[E]) SetComparator(comparator util.Comparator[E]) {
+	pq.purge()
+	pq.comparator = comparator
+	pq.heap.reorder(comparator)
+}
+
+// CheckInvariants verifies the internal consistency of this queue, and returns an error describing the first violation.
+// It is intended for tests, and for detecting comparators that are inconsistent or change the ordering of stored elements.
+// Building with the pqdebug tag runs the same checks after every mutation and panics on a violation.
+func (pq *PriorityQueue[E]) CheckInvariants() error {
+	if pq.deleted != nil {
+		if err := pq.checkDeleted(); err != nil {
+			return err
+		}
+	}
+	if pq.maxCapacity > 0 && pq.heap.Len() > pq.maxCapacity {
+		return fmt.Errorf("priorityqueue: %d elements exceed max capacity %d", pq.heap.Len(), pq.maxCapacity)
+	}
+	return pq.heap.check()
+}
+
+// compare compares a and b with the current comparator of this queue.
+func (pq *PriorityQueue[E]) compare(a, b E) int {
+	return pq.comparator(a, b)
 }
 
 // Returns true if this queue contains the specified element.
 // boolean contains(Object o)
 func (pq *PriorityQueue[E]) Contains(item E) bool {
-	// when type is comparable
-	if reflect.TypeOf(item).Comparable() {
-		for _, v := range pq.heap.items {
-			if reflect.ValueOf(v).Interface() == reflect.ValueOf(item).Interface() {
-				return true
-			}
+	pq.purge()
+	for v := range pq.heap.all() {
+		if pq.equals(v, item) {
+			return true
 		}
-		return false
 	}
+	return false
+}
 
-	// panic if not comparable and equals function is not provided
-	if pq.equals == nil {
-		panic("Type is not comparable and equals function is not provided")
+// Returns true if this queue contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (pq *PriorityQueue[E]) ContainsAll(items []E) bool {
+	for _, item := range items {
+		if !pq.Contains(item) {
+			return false
+		}
 	}
+	return true
+}
 
-	// use equals function
-	for _, v := range pq.heap.items {
-		if pq.equals(v, item) {
-			return true
+// Returns true if this queue contains all of the elements in the specified queue.
+// boolean containsAll(Collection<?> c)
+func (pq *PriorityQueue[E]) ContainsAllFrom(other *PriorityQueue[E]) bool {
+	return pq.ContainsAll(other.elements())
+}
+
+// Removes at most the given number of elements from this queue and appends them to dst in priority order.
+// Returns the number of elements transferred. Use math.MaxInt to drain the whole queue.
+// int drainTo(Collection<? super E> c, int maxElements)
+func (pq *PriorityQueue[E]) DrainTo(dst *[]E, maxElements int) int {
+	if dst == nil {
+		panic("Destination is nil")
+	}
+
+	n := min(maxElements, pq.Size())
+	if n <= 0 {
+		return 0
+	}
+	for i := 0; i < n; i++ {
+		*dst = append(*dst, pq.Poll())
+	}
+	return n
+}
+
+// ElementsEqual reports whether this queue and the other queue contain the same elements with the same multiplicities,
+// regardless of the internal heap layout. Elements are compared using the equality of this queue.
+func (pq *PriorityQueue[E]) ElementsEqual(other *PriorityQueue[E]) bool {
+	if other == pq {
+		return true
+	}
+	if other == nil || pq.Size() != other.Size() {
+		return false
+	}
+	pq.purge()
+	others := other.elements()
+
+	matched := make([]bool, len(others))
+	for v := range pq.heap.all() {
+		found := false
+		for j, w := range others {
+			if !matched[j] && pq.equals(v, w) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
 		}
 	}
-	return false
+	return true
+}
+
+// Performs the given action for each element of this queue, in no particular order.
+// void forEach(Consumer<? super E> action)
+func (pq *PriorityQueue[E]) ForEach(action util.Consumer[E]) {
+	for _, v := range pq.elements() {
+		action(v)
+	}
 }
 
 // Retrieves and removes the head of this queue, or returns null if this queue is empty.
 // E poll()
 func (pq *PriorityQueue[E]) Poll() E {
-	item := heap.Pop(pq.heap)
-	if item == nil {
+	item, _ := pq.PollE()
+	return item
+}
+
+// PollE retrieves and removes the head of this queue.
+// It returns ErrNoSuchElement if this queue is empty.
+func (pq *PriorityQueue[E]) PollE() (E, error) {
+	pq.skipDeleted()
+	if pq.heap.Len() == 0 {
+		var zero E
+		return zero, ErrNoSuchElement
+	}
+	item := pq.heap.pop()
+	pq.notifyPoll(item)
+	return item, nil
+}
+
+// PollOK retrieves and removes the head of this queue.
+// The boolean result is false if this queue is empty, which distinguishes it from a zero value element.
+func (pq *PriorityQueue[E]) PollOK() (E, bool) {
+	item, err := pq.PollE()
+	return item, err == nil
+}
+
+// Retrieves and removes the head of this queue.
+// It panics if this queue is empty, unlike Poll.
+// E remove()
+func (pq *PriorityQueue[E]) RemoveHead() E {
+	item, err := pq.PollE()
+	if err != nil {
+		panic("No such element")
+	}
+	return item
+}
+
+// PollN retrieves and removes at most k elements from the head of this queue, in priority order.
+func (pq *PriorityQueue[E]) PollN(k int) []E {
+	items := make([]E, 0, max(0, min(k, pq.Size())))
+	pq.DrainTo(&items, k)
+	return items
+}
+
+// PollWhile retrieves and removes elements from the head of this queue as long as the head satisfies the given predicate,
+// and returns them in priority order.
+func (pq *PriorityQueue[E]) PollWhile(predicate util.Predicate[E]) []E {
+	var items []E
+	for {
+		head, ok := pq.PeekOK()
+		if !ok || !predicate(head) {
+			break
+		}
+		items = append(items, pq.Poll())
+	}
+	return items
+}
+
+// NSmallest returns at most k elements from the head of this queue, in priority order, without removing them.
+// It explores only the top of the heap, in O(k log k) time for the d-ary heap.
+func (pq *PriorityQueue[E]) NSmallest(k int) []E {
+	pq.purge()
+	k = min(k, pq.heap.Len())
+	if k <= 0 {
+		return []E{}
+	}
+	return pq.heap.smallest(k)
+}
+
+// PushPop inserts the specified element and then retrieves and removes the head of this queue, using a single sift.
+// If the element would be polled first, it is returned immediately and the queue is left unchanged.
+func (pq *PriorityQueue[E]) PushPop(item E) E {
+	pq.skipDeleted()
+	pq.notifyOffer(item)
+	head := item
+	if pq.Size() > 0 {
+		c := pq.compare(item, pq.heap.peek())
+		if c > 0 || (c == 0 && pq.stable) {
+			head = pq.heap.peek()
+			pq.heap.replaceRoot(item)
+		}
+	}
+	pq.notifyPoll(head)
+	return head
+}
+
+// ReplaceHead retrieves and removes the head of this queue and then inserts the specified element, using a single sift.
+// The returned head may rank after the inserted element. If this queue is empty, the element is inserted and zero value is returned.
+func (pq *PriorityQueue[E]) ReplaceHead(item E) E {
+	pq.skipDeleted()
+	if pq.Size() == 0 {
+		pq.Add(item)
 		var zero E
 		return zero
 	}
-	return item.(E)
+	head := pq.heap.peek()
+	pq.heap.replaceRoot(item)
+	pq.notifyPoll(head)
+	pq.notifyOffer(item)
+	return head
 }
 
 // Retrieves, but does not remove, the head of this queue, or returns null if this queue is empty.
 // E peek()
 func (pq *PriorityQueue[E]) Peek() E {
+	item, _ := pq.PeekE()
+	return item
+}
+
+// PeekE retrieves, but does not remove, the head of this queue.
+// It returns ErrNoSuchElement if this queue is empty.
+func (pq *PriorityQueue[E]) PeekE() (E, error) {
+	pq.skipDeleted()
 	if pq.heap.Len() == 0 {
 		var zero E
-		return zero
+		return zero, ErrNoSuchElement
 	}
-	return pq.heap.items[0]
+	return pq.heap.peek(), nil
+}
+
+// PeekOK retrieves, but does not remove, the head of this queue.
+// The boolean result is false if this queue is empty, which distinguishes it from a zero value element.
+func (pq *PriorityQueue[E]) PeekOK() (E, bool) {
+	item, err := pq.PeekE()
+	return item, err == nil
+}
+
+// Retrieves, but does not remove, the head of this queue.
+// It panics if this queue is empty, unlike Peek.
+// E element()
+func (pq *PriorityQueue[E]) Element() E {
+	item, err := pq.PeekE()
+	if err != nil {
+		panic("No such element")
+	}
+	return item
 }
 
 // Removes the specified element from this queue if it is present.
 // boolean remove(Object o)
 func (pq *PriorityQueue[E]) Remove(item E) bool {
-	// when type is comparable
-	if reflect.TypeOf(item).Comparable() {
-		for i, v := range pq.heap.items {
-			if reflect.ValueOf(v).Interface() == reflect.ValueOf(item).Interface() {
-				heap.Remove(pq.heap, i)
-				return true
-			}
+	if pq.deleted != nil {
+		k := any(item)
+		if pq.heap.occurrences(k) <= pq.deleted[k] {
+			return false
 		}
+		pq.deleted[k]++
+		pq.deletedCount++
+		pq.notifyRemove(item)
+		return true
+	}
+
+	pq.purge()
+	removed, ok := pq.heap.removeFunc(func(v E) bool {
+		return pq.equals(v, item)
+	})
+	if !ok {
 		return false
 	}
+	pq.notifyRemove(removed)
+	return true
+}
 
-	// panic if not comparable and equals function is not provided
-	if pq.equals == nil {
-		panic("Type is not comparable and equals function is not provided")
+// Removes all of this queue's elements that are also contained in the specified slice.
+// The heap is rebuilt once after all matching elements have been removed.
+// boolean removeAll(Collection<?> c)
+func (pq *PriorityQueue[E]) RemoveAll(items []E) bool {
+	return pq.RemoveIf(func(v E) bool {
+		return pq.sliceContains(items, v)
+	})
+}
+
+// Removes all of this queue's elements that are also contained in the specified queue.
+// boolean removeAll(Collection<?> c)
+func (pq *PriorityQueue[E]) RemoveAllFrom(other *PriorityQueue[E]) bool {
+	if other == pq {
+		if pq.Size() == 0 {
+			return false
+		}
+		pq.Clear()
+		return true
 	}
+	return pq.RemoveAll(other.elements())
+}
 
-	// use equals function
-	for i, v := range pq.heap.items {
-		if pq.equals(v, item) {
-			heap.Remove(pq.heap, i)
+// Retains only the elements in this queue that are contained in the specified slice.
+// The heap is rebuilt once after all other elements have been removed.
+// boolean retainAll(Collection<?> c)
+func (pq *PriorityQueue[E]) RetainAll(items []E) bool {
+	return pq.RemoveIf(func(v E) bool {
+		return !pq.sliceContains(items, v)
+	})
+}
+
+// Retains only the elements in this queue that are contained in the specified queue.
+// boolean retainAll(Collection<?> c)
+func (pq *PriorityQueue[E]) RetainAllFrom(other *PriorityQueue[E]) bool {
+	if other == pq {
+		return false
+	}
+	return pq.RetainAll(other.elements())
+}
+
+// Removes all of the elements of this queue that satisfy the given predicate.
+// The heap is rebuilt once after all matching elements have been removed.
+// boolean removeIf(Predicate<? super E> filter)
+func (pq *PriorityQueue[E]) RemoveIf(filter util.Predicate[E]) bool {
+	pq.purge()
+	return pq.heap.removeIf(func(v E) bool {
+		if filter(v) {
+			pq.notifyRemove(v)
 			return true
 		}
+		return false
+	})
+}
+
+// Returns the number of additional elements that this queue can accept, or math.MaxInt if it is unbounded.
+// int remainingCapacity()
+func (pq *PriorityQueue[E]) RemainingCapacity() int {
+	if pq.maxCapacity <= 0 {
+		return math.MaxInt
+	}
+	return pq.maxCapacity - pq.Size()
+}
+
+// Returns the capacity of the backing slice, that is, the number of elements this queue can hold without reallocating.
+// A pairing heap has no backing slice, so its capacity is the number of elements it holds.
+func (pq *PriorityQueue[E]) Capacity() int {
+	h, ok := pq.heap.(*internalHeap[E])
+	if !ok {
+		return pq.heap.Len()
+	}
+	return cap(h.items)
+}
+
+// Increases the capacity of the backing slice, if necessary, so that it can hold at least minCapacity elements without reallocating.
+// It does nothing for a pairing heap.
+// void ensureCapacity(int minCapacity)
+func (pq *PriorityQueue[E]) EnsureCapacity(minCapacity int) {
+	if h, ok := pq.heap.(*internalHeap[E]); ok && minCapacity > cap(h.items) {
+		h.resize(minCapacity)
+	}
+}
+
+// Trims the capacity of the backing slice to the number of elements in this queue, releasing unused memory.
+// It only compacts the deleted elements of lazy deletion mode for a pairing heap.
+// void trimToSize()
+func (pq *PriorityQueue[E]) TrimToSize() {
+	pq.purge()
+	if h, ok := pq.heap.(*internalHeap[E]); ok && cap(h.items) > len(h.items) {
+		h.resize(len(h.items))
 	}
-	return false
+}
+
+// Returns true if this queue contains no elements.
+// boolean isEmpty()
+func (pq *PriorityQueue[E]) IsEmpty() bool {
+	return pq.Size() == 0
 }
 
 // Returns the number of elements in this queue.
 // int size()
 func (pq *PriorityQueue[E]) Size() int {
-	return len(pq.heap.items)
+	return max(pq.heap.Len()-pq.deletedCount, 0)
 }
 
 // Returns an array containing all of the elements in this queue.
 // Object[] toArray()
 func (pq *PriorityQueue[E]) ToArray() []E {
-	return append([]E(nil), pq.heap.items...)
+	pq.purge()
+	return slices.AppendSeq(make([]E, 0, pq.heap.Len()), pq.heap.all())
+}
+
+// Returns an array containing all of the elements in this queue, in priority order.
+// Unlike ToArray, the result is sorted the same way the elements would be polled.
+func (pq *PriorityQueue[E]) ToSortedArray() []E {
+	return pq.NSmallest(pq.Size())
+}
+
+// Returns a string representation of this queue, in the form "[e1, e2, e3]".
+// The elements are listed in the order of the backing slice, and formatted with the formatter if one is set.
+// String toString()
+func (pq *PriorityQueue[E]) String() string {
+	pq.purge()
+	var sb strings.Builder
+	sb.WriteByte('[')
+	i := 0
+	for v := range pq.heap.all() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if pq.formatter != nil {
+			sb.WriteString(pq.formatter(v))
+		} else {
+			fmt.Fprint(&sb, v)
+		}
+		i++
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// defaultEquals returns the equality function used when none is provided.
+// Elements are compared by util.DefaultEquals, except that elements of a type that cannot be compared with ==
+// are considered equal when the comparator returns 0, unless deep is set.
+func defaultEquals[E any](comparator util.Comparator[E], deep bool) util.Equals[E] {
+	if !deep && !reflect.TypeFor[E]().Comparable() {
+		return func(a, b E) bool {
+			return comparator(a, b) == 0
+		}
+	}
+	return util.DefaultEquals[E]()
+}
+
+// orderedElements returns the elements of this queue like elements, but in priority order with WithStableOrdering,
+// so that inserting them in turn into a stable queue keeps equal elements in their insertion order.
+func (pq *PriorityQueue[E]) orderedElements() []E {
+	if pq.stable {
+		return pq.ToSortedArray()
+	}
+	return pq.elements()
+}
+
+// meldable returns the pairing heaps of this queue and the other queue if Merge can meld them without inserting
+// the elements of the other queue one by one, which requires the same ordering and no observers to notify.
+// Before it reports true, it compacts the deleted elements of the other queue and checks that they fit within the maximum capacity.
+func (pq *PriorityQueue[E]) meldable(other *PriorityQueue[E]) (ph, oh *pairingHeap[E], ok bool) {
+	ph, ok = pq.heap.(*pairingHeap[E])
+	if !ok {
+		return nil, nil, false
+	}
+	oh, ok = other.heap.(*pairingHeap[E])
+	if !ok || pq.topK > 0 || len(pq.observers) > 0 || len(other.observers) > 0 ||
+		ph.stable != oh.stable || (ph.counts == nil) != (oh.counts == nil) {
+		return nil, nil, false
+	}
+	other.purge()
+	if pq.maxCapacity > 0 && pq.heap.Len()+oh.Len() > pq.maxCapacity {
+		return nil, nil, false
+	}
+	return ph, oh, true
+}
+
+// elements returns the elements of this queue, in no particular order, after compacting the deleted elements.
+// For the d-ary heap it returns the backing slice, which must not be modified.
+func (pq *PriorityQueue[E]) elements() []E {
+	pq.purge()
+	if h, ok := pq.heap.(*internalHeap[E]); ok {
+		return h.items
+	}
+	return slices.Collect(pq.heap.all())
+}
+
+// sliceContains reports whether items contains an element equal to item.
+func (pq *PriorityQueue[E]) sliceContains(items []E, item E) bool {
+	for _, v := range items {
+		if pq.equals(v, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipDeleted removes the elements at the head of the heap that were deleted in lazy deletion mode.
+// Any occurrence of a deleted element stands for it, as occurrences cannot be told apart with ==.
+func (pq *PriorityQueue[E]) skipDeleted() {
+	for pq.deletedCount > 0 && pq.heap.Len() > 0 {
+		k := any(pq.heap.peek())
+		if pq.deleted[k] == 0 {
+			return
+		}
+		pq.undelete(k)
+		pq.heap.pop()
+	}
 }
 
-// internalHeap is an internal type that implements heap.Interface.
+// purge removes from the heap all of the elements that were deleted in lazy deletion mode.
+func (pq *PriorityQueue[E]) purge() {
+	if pq.deletedCount == 0 {
+		return
+	}
+	pq.heap.removeIf(func(v E) bool {
+		k := any(v)
+		if pq.deleted[k] == 0 {
+			return false
+		}
+		pq.undelete(k)
+		return true
+	})
+}
+
+// undelete decrements the count of deleted occurrences of the element with key k, once one of them has left the heap.
+func (pq *PriorityQueue[E]) undelete(k any) {
+	if pq.deleted[k] == 1 {
+		delete(pq.deleted, k)
+	} else {
+		pq.deleted[k]--
+	}
+	pq.deletedCount--
+}
+
+// occurrenceCounts holds the number of occurrences of each element of a heap in lazy deletion mode,
+// keyed by the element itself. A nil occurrenceCounts counts nothing.
+type occurrenceCounts[E any] map[any]int
+
+// add adds delta to the number of occurrences of the element.
+func (c occurrenceCounts[E]) add(item E, delta int) {
+	if c == nil {
+		return
+	}
+	k := any(item)
+	if n := c[k] + delta; n > 0 {
+		c[k] = n
+	} else {
+		delete(c, k)
+	}
+}
+
+// reset replaces the counts with the numbers of occurrences of the given elements.
+func (c occurrenceCounts[E]) reset(items iter.Seq[E]) {
+	if c == nil {
+		return
+	}
+	clear(c)
+	for item := range items {
+		c[any(item)]++
+	}
+}
+
+// check verifies that the counts match the numbers of occurrences of the given elements.
+func (c occurrenceCounts[E]) check(items iter.Seq[E]) error {
+	if c == nil {
+		return nil
+	}
+	actual := make(occurrenceCounts[E], len(c))
+	actual.reset(items)
+	if !maps.Equal(actual, c) {
+		return errors.New("priorityqueue: occurrence counts do not match the elements")
+	}
+	return nil
+}
+
+// checkDeleted verifies that every element deleted in lazy deletion mode is still in the heap.
+func (pq *PriorityQueue[E]) checkDeleted() error {
+	total := 0
+	for k, n := range pq.deleted {
+		if c := pq.heap.occurrences(k); n > c {
+			return fmt.Errorf("priorityqueue: %d deleted occurrences of %v for %d occurrences", n, k, c)
+		}
+		total += n
+	}
+	if total != pq.deletedCount {
+		return fmt.Errorf("priorityqueue: %d deleted elements counted as %d", total, pq.deletedCount)
+	}
+	return nil
+}
+
+// retainTopK inserts the element into a queue in top-K mode, evicting the head if the queue is full.
+// It reports whether the element was retained, and returns the evicted head with hasEvicted set to true, if any.
+func (pq *PriorityQueue[E]) retainTopK(item E) (evicted E, hasEvicted, retained bool) {
+	pq.skipDeleted()
+	if pq.Size() < pq.topK {
+		pq.heap.push(item)
+		return evicted, false, true
+	}
+	if pq.compare(item, pq.heap.peek()) <= 0 {
+		return evicted, false, false
+	}
+	evicted = pq.heap.peek()
+	pq.heap.replaceRoot(item)
+	return evicted, true, true
+}
+
+// notifyOffer calls the OnOffer callback of every observer.
+func (pq *PriorityQueue[E]) notifyOffer(item E) {
+	for _, o := range pq.observers {
+		if o.OnOffer != nil {
+			o.OnOffer(item)
+		}
+	}
+}
+
+// notifyPoll calls the OnPoll callback of every observer.
+func (pq *PriorityQueue[E]) notifyPoll(item E) {
+	for _, o := range pq.observers {
+		if o.OnPoll != nil {
+			o.OnPoll(item)
+		}
+	}
+}
+
+// notifyRemove calls the OnRemove callback of every observer.
+func (pq *PriorityQueue[E]) notifyRemove(item E) {
+	for _, o := range pq.observers {
+		if o.OnRemove != nil {
+			o.OnRemove(item)
+		}
+	}
+}
+
+// removeAt removes the element at index i of the d-ary heap h of this queue.
+// If the last element was moved to a position before i while restoring the heap, it is returned with movedUp set to true.
+func (pq *PriorityQueue[E]) removeAt(h *internalHeap[E], i int) (moved E, movedUp bool) {
+	n := len(h.items) - 1
+	if i == n {
+		pq.notifyRemove(h.removeLast())
+		return moved, false
+	}
+
+	last := h.items[n]
+	movedUp = i > 0 && h.Less(n, h.parent(i))
+	pq.notifyRemove(h.remove(i))
+	if movedUp {
+		return last, true
+	}
+	return moved, false
+}
+
+// internalHeap is an internal type that implements a d-ary heap.
+// It also backs IndexedPriorityQueue and KeyedPriorityQueue, which track the positions of their elements with moved.
 type internalHeap[E any] struct {
 	items      []E
 	comparator util.Comparator[E]
+
+	// arity is the number of children of each node.
+	arity int
+
+	// shrinkThreshold is the fraction of the capacity below which the backing slice is shrunk, or 0 to never shrink.
+	shrinkThreshold float64
+
+	// seqs holds the insertion sequence number of each element when stable is set, and is nil otherwise.
+	seqs    []uint64
+	nextSeq uint64
+	stable  bool
+
+	// counts holds the number of occurrences of each element in lazy deletion mode, and is nil otherwise.
+	counts occurrenceCounts[E]
+
+	// moved is called with an element and its index whenever the element is placed at a new index of the backing slice,
+	// for heaps that track the positions of their elements, and is nil otherwise.
+	moved func(item E, i int)
+}
+
+// appendItems appends the given elements to the backing slice without restoring the heap order.
+func (ph *internalHeap[E]) appendItems(items ...E) {
+	n := len(ph.items)
+	ph.items = append(ph.items, items...)
+	for i, item := range items {
+		ph.counts.add(item, 1)
+		if ph.moved != nil {
+			ph.moved(item, n+i)
+		}
+	}
+	if ph.stable {
+		for range items {
+			ph.seqs = append(ph.seqs, ph.nextSeq)
+			ph.nextSeq++
+		}
+	}
+}
+
+// setItems replaces the backing slice with the given elements and rebuilds the heap.
+func (ph *internalHeap[E]) setItems(items []E) {
+	ph.items = items
+	ph.seqs = nil
+	ph.counts.reset(slices.Values(items))
+	if ph.moved != nil {
+		for i, item := range items {
+			ph.moved(item, i)
+		}
+	}
+	if ph.stable {
+		ph.seqs = make([]uint64, len(items))
+		for i := range ph.seqs {
+			ph.seqs[i] = ph.nextSeq
+			ph.nextSeq++
+		}
+	}
+	ph.init()
+}
+
+// replaceRoot replaces the element at the root of the non-empty heap and restores the heap order.
+func (ph *internalHeap[E]) replaceRoot(item E) {
+	ph.counts.add(ph.items[0], -1)
+	ph.counts.add(item, 1)
+	ph.items[0] = item
+	if ph.moved != nil {
+		ph.moved(item, 0)
+	}
+	if ph.stable {
+		ph.seqs[0] = ph.nextSeq
+		ph.nextSeq++
+	}
+	ph.fix(0)
+}
+
+// removeIf removes all of the elements that satisfy the given predicate and rebuilds the heap once.
+func (ph *internalHeap[E]) removeIf(filter func(E) bool) bool {
+	kept := ph.items[:0]
+	for i, v := range ph.items {
+		if filter(v) {
+			ph.counts.add(v, -1)
+			continue
+		}
+		if ph.stable {
+			ph.seqs[len(kept)] = ph.seqs[i]
+		}
+		if ph.moved != nil {
+			ph.moved(v, len(kept))
+		}
+		kept = append(kept, v)
+	}
+	if len(kept) == len(ph.items) {
+		return false
+	}
+
+	// clear the tail so removed elements can be garbage collected
+	clear(ph.items[len(kept):])
+	ph.items = kept
+	if ph.stable {
+		ph.seqs = ph.seqs[:len(kept)]
+	}
+	ph.shrink()
+	ph.init()
+	return true
+}
+
+// minShrinkCapacity is the capacity below which the backing slice is never shrunk automatically.
+const minShrinkCapacity = 16
+
+// shrink reallocates the backing slice with twice the current number of elements
+// if the number of elements has dropped below the shrink threshold.
+func (ph *internalHeap[E]) shrink() {
+	c := cap(ph.items)
+	if ph.shrinkThreshold == 0 || c <= minShrinkCapacity || float64(len(ph.items)) >= float64(c)*ph.shrinkThreshold {
+		return
+	}
+	ph.resize(max(2*len(ph.items), minShrinkCapacity))
+}
+
+// resize reallocates the backing slice with the given capacity, which must not be less than the number of elements.
+func (ph *internalHeap[E]) resize(capacity int) {
+	items := make([]E, len(ph.items), capacity)
+	copy(items, ph.items)
+	ph.items = items
+	if ph.stable {
+		seqs := make([]uint64, len(ph.seqs), capacity)
+		copy(seqs, ph.seqs)
+		ph.seqs = seqs
+	}
 }
 
 // Len is the number of elements in the collection.
-// It is used by the heap package.
-func (ph internalHeap[E]) Len() int {
+// It is used by the sift functions.
+func (ph *internalHeap[E]) Len() int {
 	return len(ph.items)
 }
 
 // Less reports whether the element with index i should sort before the element with index j.
-// It is used by the heap package.
-func (ph internalHeap[E]) Less(i, j int) bool {
-	return ph.comparator(ph.items[i], ph.items[j]) < 0
+// It is used by the sift functions.
+func (ph *internalHeap[E]) Less(i, j int) bool {
+	c := ph.comparator(ph.items[i], ph.items[j])
+	if c == 0 && ph.stable {
+		return ph.seqs[i] < ph.seqs[j]
+	}
+	return c < 0
 }
 
 // Swap swaps the elements with indexes i and j.
-// It is used by the heap package.
+// It is used by the sift functions.
 func (ph *internalHeap[E]) Swap(i, j int) {
 	ph.items[i], ph.items[j] = ph.items[j], ph.items[i]
-}
-
-// Push pushes the element x onto the heap.
-// It is used by the heap package.
-func (ph *internalHeap[E]) Push(x any) {
-	item, ok := x.(E)
-	if !ok {
-		return
+	if ph.stable {
+		ph.seqs[i], ph.seqs[j] = ph.seqs[j], ph.seqs[i]
+	}
+	if ph.moved != nil {
+		ph.moved(ph.items[i], i)
+		ph.moved(ph.items[j], j)
 	}
-	ph.items = append(ph.items, item)
 }
 
-// Pop removes and returns the minimum element (according to Less) from the heap.
-// It is used by the heap package.
-func (ph *internalHeap[E]) Pop() any {
+// removeLast removes and returns the last element of the backing slice.
+func (ph *internalHeap[E]) removeLast() E {
 	old := ph.items
 	n := len(old)
 	item := old[n-1]
+	ph.counts.add(item, -1)
+	var zero E
+	old[n-1] = zero
 	ph.items = old[0 : n-1]
+	if ph.stable {
+		ph.seqs = ph.seqs[0 : n-1]
+	}
+	ph.shrink()
 	return item
 }
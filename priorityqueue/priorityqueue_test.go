@@ -0,0 +1,63 @@
+package priorityqueue
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	pq := New(WithInitialItems(5, 1, 3))
+	other := New(WithInitialItems(4, 2))
+	if err := pq.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("ToSortedArray() = %v, want [1 2 3 4 5]", got)
+	}
+	if !other.IsEmpty() {
+		t.Errorf("other queue holds %v after Merge, want it empty", other)
+	}
+}
+
+func TestMergeIntoFullQueue(t *testing.T) {
+	pq := New(WithMaxCapacity[int](3), WithInitialItems(1, 2))
+	other := New(WithInitialItems(3, 4))
+	if err := pq.Merge(other); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Merge() = %v, want ErrQueueFull", err)
+	}
+	if got := pq.ToSortedArray(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("ToSortedArray() = %v, want [1 2] unchanged", got)
+	}
+	if got := other.ToSortedArray(); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("other ToSortedArray() = %v, want [3 4] unchanged", got)
+	}
+}
+
+func TestDefaultEquals(t *testing.T) {
+	pq := New(WithComparator(func(a, b any) int { return 0 }), WithInitialItems[any]([]int{1}, "a"))
+	if !pq.Contains([]int{1}) || !pq.Contains("a") || pq.Contains([]int{2}) {
+		t.Error("Contains() must compare interface elements with == or reflect.DeepEqual")
+	}
+
+	bytes := NewOrdered(WithInitialItems[int8](3, 1, 2))
+	if !bytes.Remove(2) || bytes.Contains(2) {
+		t.Error("Remove(2) must remove the int8 element 2")
+	}
+}
+
+func TestMergeStable(t *testing.T) {
+	pq := New(WithComparator(byJobPriority), WithStableOrdering[job](), WithInitialItems(job{1, "0"}))
+	if err := pq.Merge(newStableJobs()); err != nil {
+		t.Fatal(err)
+	}
+	if got := pollNames(pq); got != "0abcdef" {
+		t.Errorf("polled %s, want 0abcdef", got)
+	}
+
+	pq = New(WithComparator(byJobPriority), WithStableOrdering[job]())
+	pq.AddAllFrom(newStableJobs())
+	if got := pollNames(pq); got != "abcdef" {
+		t.Errorf("polled %s after AddAllFrom, want abcdef", got)
+	}
+}
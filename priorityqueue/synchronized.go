@@ -0,0 +1,207 @@
+package priorityqueue
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// SynchronizedPriorityQueue is a thread-safe PriorityQueue.
+// Every operation acquires a lock on the whole queue, so each method call is atomic with respect to the others.
+// Sequences of calls, such as Peek followed by Poll, are not atomic and must be synchronized by the caller.
+type SynchronizedPriorityQueue[E any] struct {
+	mu sync.RWMutex
+	pq *PriorityQueue[E]
+}
+
+// NewSynchronized creates a new SynchronizedPriorityQueue with the given options.
+func NewSynchronized[E any](opts ...Option[E]) *SynchronizedPriorityQueue[E] {
+	return &SynchronizedPriorityQueue[E]{
+		pq: New(opts...),
+	}
+}
+
+// Synchronized returns a SynchronizedPriorityQueue backed by the given queue.
+// The given queue must not be accessed directly afterwards, or the thread-safety guarantees no longer hold.
+// static <T> Collection<T> synchronizedCollection(Collection<T> c)
+func Synchronized[E any](pq *PriorityQueue[E]) *SynchronizedPriorityQueue[E] {
+	return &SynchronizedPriorityQueue[E]{
+		pq: pq,
+	}
+}
+
+// rlock locks the queue for a method that only reads it, and returns the function that unlocks it.
+// In lazy deletion mode, reading the elements compacts the deleted ones, so the queue is locked for writing instead.
+func (s *SynchronizedPriorityQueue[E]) rlock() (unlock func()) {
+	if s.pq.deleted != nil {
+		s.mu.Lock()
+		return s.mu.Unlock
+	}
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+// Inserts the specified element into this priority queue.
+// boolean add(E e)
+func (s *SynchronizedPriorityQueue[E]) Add(item E) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.Add(item)
+}
+
+// Inserts the specified element into this priority queue.
+// boolean offer(E e)
+func (s *SynchronizedPriorityQueue[E]) Offer(item E) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.Offer(item)
+}
+
+// Adds all of the elements in the specified slice to this queue.
+// boolean addAll(Collection<? extends E> c)
+func (s *SynchronizedPriorityQueue[E]) AddAll(items []E) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.AddAll(items)
+}
+
+// Removes all of the elements from this priority queue.
+// void clear()
+func (s *SynchronizedPriorityQueue[E]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pq.Clear()
+}
+
+// Returns the comparator used to order the elements in this queue.
+// Comparator<? super E> comparator()
+func (s *SynchronizedPriorityQueue[E]) Comparator() util.Comparator[E] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pq.Comparator()
+}
+
+// Returns true if this queue contains the specified element.
+// boolean contains(Object o)
+func (s *SynchronizedPriorityQueue[E]) Contains(item E) bool {
+	defer s.rlock()()
+	return s.pq.Contains(item)
+}
+
+// Returns true if this queue contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (s *SynchronizedPriorityQueue[E]) ContainsAll(items []E) bool {
+	defer s.rlock()()
+	return s.pq.ContainsAll(items)
+}
+
+// Removes at most the given number of elements from this queue and appends them to dst in priority order.
+// int drainTo(Collection<? super E> c, int maxElements)
+func (s *SynchronizedPriorityQueue[E]) DrainTo(dst *[]E, maxElements int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.DrainTo(dst, maxElements)
+}
+
+// Performs the given action for each element of this queue, in no particular order.
+// The lock is held while the action runs, so the action must not call methods of this queue.
+// void forEach(Consumer<? super E> action)
+func (s *SynchronizedPriorityQueue[E]) ForEach(action util.Consumer[E]) {
+	defer s.rlock()()
+	s.pq.ForEach(action)
+}
+
+// All returns an iterator over a snapshot of the elements in this queue, taken when iteration starts.
+func (s *SynchronizedPriorityQueue[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, v := range s.ToArray() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a weakly consistent iterator over a snapshot of the elements in this queue.
+// Iterator<E> iterator()
+func (s *SynchronizedPriorityQueue[E]) Iterator() *SnapshotIterator[E] {
+	return newSnapshotIterator(s.ToArray(), s.Remove)
+}
+
+// Retrieves and removes the head of this queue, or returns zero value if this queue is empty.
+// E poll()
+func (s *SynchronizedPriorityQueue[E]) Poll() E {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.Poll()
+}
+
+// Retrieves, but does not remove, the head of this queue, or returns zero value if this queue is empty.
+// E peek()
+func (s *SynchronizedPriorityQueue[E]) Peek() E {
+	defer s.rlock()()
+	return s.pq.Peek()
+}
+
+// Removes the specified element from this queue if it is present.
+// boolean remove(Object o)
+func (s *SynchronizedPriorityQueue[E]) Remove(item E) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.Remove(item)
+}
+
+// Removes all of this queue's elements that are also contained in the specified slice.
+// boolean removeAll(Collection<?> c)
+func (s *SynchronizedPriorityQueue[E]) RemoveAll(items []E) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.RemoveAll(items)
+}
+
+// Retains only the elements in this queue that are contained in the specified slice.
+// boolean retainAll(Collection<?> c)
+func (s *SynchronizedPriorityQueue[E]) RetainAll(items []E) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.RetainAll(items)
+}
+
+// Removes all of the elements of this queue that satisfy the given predicate.
+// boolean removeIf(Predicate<? super E> filter)
+func (s *SynchronizedPriorityQueue[E]) RemoveIf(filter util.Predicate[E]) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pq.RemoveIf(filter)
+}
+
+// Returns the number of additional elements that this queue can accept, or math.MaxInt if it is unbounded.
+// int remainingCapacity()
+func (s *SynchronizedPriorityQueue[E]) RemainingCapacity() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pq.RemainingCapacity()
+}
+
+// Returns the number of elements in this queue.
+// int size()
+func (s *SynchronizedPriorityQueue[E]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pq.Size()
+}
+
+// Returns an array containing all of the elements in this queue.
+// Object[] toArray()
+func (s *SynchronizedPriorityQueue[E]) ToArray() []E {
+	defer s.rlock()()
+	return s.pq.ToArray()
+}
+
+// Returns a string representation of this queue, in the form "[e1, e2, e3]".
+// String toString()
+func (s *SynchronizedPriorityQueue[E]) String() string {
+	defer s.rlock()()
+	return s.pq.String()
+}
@@ -0,0 +1,41 @@
+package priorityqueue
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSynchronizedLazyDeletionReaders(t *testing.T) {
+	s := NewSynchronized(WithLazyDeletion[int]())
+	for i := range 100 {
+		s.Add(i)
+	}
+
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := g; i < 100; i += 4 {
+				s.Remove(i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				s.ToArray()
+				s.Peek()
+				s.Contains(g)
+				_ = s.String()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := s.Size(); got != 0 {
+		t.Errorf("Size() = %d, want 0", got)
+	}
+	if got := len(s.ToArray()); got != 0 {
+		t.Errorf("len(ToArray()) = %d, want 0", got)
+	}
+}
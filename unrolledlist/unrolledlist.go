@@ -0,0 +1,548 @@
+package unrolledlist
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/nsce9806q/javastyle-collection/collection"
+	"github.com/nsce9806q/javastyle-collection/collections"
+	"github.com/nsce9806q/javastyle-collection/internal/listutil"
+	"github.com/nsce9806q/javastyle-collection/util"
+)
+
+// defaultNodeCapacity is the number of elements a node holds when no WithNodeCapacity option is given.
+const defaultNodeCapacity = 32
+
+// UnrolledList is an unrolled linked list: a doubly-linked list of nodes that each hold a small array of elements.
+// It is a middle ground between arraylist.ArrayList and linkedlist.LinkedList: insertions and removals only shift
+// the elements of one node, while the elements are stored contiguously, so it allocates one node per run of elements
+// rather than one per element and traverses memory with far better locality than a LinkedList.
+// Nodes that become less than half full are merged with their successor when the two fit in one node.
+type UnrolledList[E any] struct {
+	first *node[E]
+	last  *node[E]
+	size  int
+
+	// nodeCapacity is the maximum number of elements held by a node.
+	nodeCapacity int
+
+	equals util.Equals[E]
+
+	// hashable is set when the elements are compared with == by default, so they can be looked up in a hash set.
+	hashable bool
+
+	// modCount is the number of structural modifications, which change the size of this list.
+	// Iterations compare it with the value they expect, to fail fast instead of traversing a list modified under them.
+	modCount int
+}
+
+// node is a node of an UnrolledList, holding between one and nodeCapacity elements.
+type node[E any] struct {
+	items []E
+	prev  *node[E]
+	next  *node[E]
+}
+
+// UnrolledList implements the List interface.
+var _ collection.List[int] = (*UnrolledList[int])(nil)
+
+// Option is a function type that sets the UnrolledList.
+type Option[E any] func(*UnrolledList[E])
+
+// WithNodeCapacity is an option that sets the maximum number of elements held by each node, 32 by default.
+// Larger nodes allocate less often and iterate faster, at the cost of shifting more elements on each insertion and removal.
+func WithNodeCapacity[E any](nodeCapacity int) Option[E] {
+	if nodeCapacity < 2 {
+		panic("Illegal capacity")
+	}
+	return func(list *UnrolledList[E]) {
+		list.nodeCapacity = nodeCapacity
+	}
+}
+
+// WithEquals is an option that sets the custom equality comparison function used by Contains, IndexOf and Remove.
+// Without it, elements are compared by util.DefaultEquals, which uses == when possible and reflect.DeepEqual otherwise.
+func WithEquals[E any](equals util.Equals[E]) Option[E] {
+	return func(list *UnrolledList[E]) {
+		list.equals = equals
+	}
+}
+
+// New creates a new empty UnrolledList with the given options.
+func New[E any](opts ...Option[E]) *UnrolledList[E] {
+	list := &UnrolledList[E]{nodeCapacity: defaultNodeCapacity}
+	for _, opt := range opts {
+		opt(list)
+	}
+	if list.equals == nil {
+		list.equals = util.DefaultEquals[E]()
+		list.hashable = listutil.Hashable[E]()
+	}
+	return list
+}
+
+// NewFromSlice creates a new UnrolledList containing the elements in the given slice, in order.
+// The slice is copied.
+func NewFromSlice[E any](items []E, opts ...Option[E]) *UnrolledList[E] {
+	list := New(opts...)
+	list.AddAll(items)
+	return list
+}
+
+// Appends the specified element to the end of this list.
+// boolean add(E e)
+func (list *UnrolledList[E]) Add(item E) bool {
+	list.AddLast(item)
+	return true
+}
+
+// Inserts the specified element at the specified position in this list,
+// shifting the elements that follow it in the same node, and splitting the node if it is full.
+// void add(int index, E element)
+func (list *UnrolledList[E]) AddAt(index int, item E) {
+	listutil.CheckIndex(index, list.size+1)
+	n, offset := list.position(index)
+	list.insertAt(n, offset, item)
+}
+
+// Appends all of the elements in the specified slice to the end of this list, in order.
+// boolean addAll(Collection<? extends E> c)
+func (list *UnrolledList[E]) AddAll(items []E) bool {
+	for _, item := range items {
+		list.AddLast(item)
+	}
+	return len(items) > 0
+}
+
+// Inserts all of the elements in the specified slice into this list at the specified position, in order.
+// The position is located once, and the elements are inserted one after another from there.
+// boolean addAll(int index, Collection<? extends E> c)
+func (list *UnrolledList[E]) AddAllAt(index int, items []E) bool {
+	listutil.CheckIndex(index, list.size+1)
+	n, offset := list.position(index)
+	for _, item := range items {
+		n, offset = list.insertAt(n, offset, item)
+	}
+	return len(items) > 0
+}
+
+// Inserts the specified element at the beginning of this list.
+// void addFirst(E e)
+func (list *UnrolledList[E]) AddFirst(item E) {
+	list.insertAt(list.first, 0, item)
+}
+
+// Appends the specified element to the end of this list.
+// void addLast(E e)
+func (list *UnrolledList[E]) AddLast(item E) {
+	if list.last == nil {
+		list.insertAt(nil, 0, item)
+		return
+	}
+	list.insertAt(list.last, len(list.last.items), item)
+}
+
+// Backward returns an iterator over the elements in this list, in reverse order, for use with range-over-func.
+// It panics if the list is structurally modified during the iteration.
+func (list *UnrolledList[E]) Backward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		expected := list.modCount
+		for n := list.last; n != nil; n = n.prev {
+			for i := len(n.items) - 1; i >= 0; i-- {
+				if !yield(n.items[i]) {
+					return
+				}
+				listutil.CheckModCount(list.modCount, expected)
+			}
+		}
+	}
+}
+
+// Removes all of the elements from this list.
+// void clear()
+func (list *UnrolledList[E]) Clear() {
+	for n := list.first; n != nil; {
+		next := n.next
+		clear(n.items)
+		*n = node[E]{}
+		n = next
+	}
+	list.first = nil
+	list.last = nil
+	list.size = 0
+	list.modCount++
+}
+
+// Returns true if this list contains the specified element.
+// boolean contains(Object o)
+func (list *UnrolledList[E]) Contains(item E) bool {
+	return list.IndexOf(item) >= 0
+}
+
+// Returns true if this list contains all of the elements in the specified slice.
+// boolean containsAll(Collection<?> c)
+func (list *UnrolledList[E]) ContainsAll(items []E) bool {
+	for _, item := range items {
+		if !list.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if the other list contains equal elements in the same order, regardless of its implementation.
+// The elements are compared with the equality function of this list.
+// boolean equals(Object o)
+func (list *UnrolledList[E]) Equals(other collection.List[E]) bool {
+	return listutil.EqualElements(list.All(), list.size, other, list.equals)
+}
+
+// Performs the given action for each element of this list, in order.
+// void forEach(Consumer<? super E> action)
+func (list *UnrolledList[E]) ForEach(action util.Consumer[E]) {
+	for v := range list.All() {
+		action(v)
+	}
+}
+
+// Returns the element at the specified position in this list.
+// The node holding it is found by walking from the nearer end of the list, skipping whole nodes at a time.
+// E get(int index)
+func (list *UnrolledList[E]) Get(index int) E {
+	listutil.CheckIndex(index, list.size)
+	n, offset := list.position(index)
+	return n.items[offset]
+}
+
+// Returns the first element in this list, and panics if this list is empty.
+// E getFirst()
+func (list *UnrolledList[E]) GetFirst() E {
+	if list.first == nil {
+		panic("No such element")
+	}
+	return list.first.items[0]
+}
+
+// Returns the last element in this list, and panics if this list is empty.
+// E getLast()
+func (list *UnrolledList[E]) GetLast() E {
+	if list.last == nil {
+		panic("No such element")
+	}
+	return list.last.items[len(list.last.items)-1]
+}
+
+// Returns the hash code of this list, computed from the hash codes of its elements in order as java.util.List specifies.
+// Equal lists have equal hash codes when their elements are compared with the default equality.
+// int hashCode()
+func (list *UnrolledList[E]) HashCode() uint64 {
+	return listutil.HashElements(list.All())
+}
+
+// Returns the index of the first occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int indexOf(Object o)
+func (list *UnrolledList[E]) IndexOf(item E) int {
+	i := 0
+	for v := range list.All() {
+		if list.equals(v, item) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// Returns the index of the last occurrence of the specified element in this list, or -1 if this list does not contain the element.
+// int lastIndexOf(Object o)
+func (list *UnrolledList[E]) LastIndexOf(item E) int {
+	i := list.size - 1
+	for v := range list.Backward() {
+		if list.equals(v, item) {
+			return i
+		}
+		i--
+	}
+	return -1
+}
+
+// Returns true if this list contains no elements.
+// boolean isEmpty()
+func (list *UnrolledList[E]) IsEmpty() bool {
+	return list.size == 0
+}
+
+// All returns an iterator over the elements in this list, in order, for use with range-over-func.
+// It panics if the list is structurally modified during the iteration.
+func (list *UnrolledList[E]) All() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		expected := list.modCount
+		for n := list.first; n != nil; n = n.next {
+			for _, v := range n.items {
+				if !yield(v) {
+					return
+				}
+				listutil.CheckModCount(list.modCount, expected)
+			}
+		}
+	}
+}
+
+// Removes the first occurrence of the specified element from this list, if it is present.
+// boolean remove(Object o)
+func (list *UnrolledList[E]) Remove(item E) bool {
+	for n := list.first; n != nil; n = n.next {
+		for i, v := range n.items {
+			if list.equals(v, item) {
+				list.removeAt(n, i)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Removes all of this list's elements that are also contained in the specified slice, in a single pass.
+// boolean removeAll(Collection<?> c)
+func (list *UnrolledList[E]) RemoveAll(items []E) bool {
+	return list.RemoveIf(listutil.Membership(items, list.equals, list.hashable))
+}
+
+// Removes the element at the specified position in this list, shifting the elements that follow it in the same node.
+// Returns the element that was removed.
+// E remove(int index)
+func (list *UnrolledList[E]) RemoveAt(index int) E {
+	listutil.CheckIndex(index, list.size)
+	n, offset := list.position(index)
+	return list.removeAt(n, offset)
+}
+
+// Removes and returns the first element of this list, and panics if this list is empty.
+// E removeFirst()
+func (list *UnrolledList[E]) RemoveFirst() E {
+	if list.first == nil {
+		panic("No such element")
+	}
+	return list.removeAt(list.first, 0)
+}
+
+// Removes all of the elements of this list that satisfy the given predicate.
+// The remaining elements are compacted into full nodes in a single pass, in O(n) time.
+// boolean removeIf(Predicate<? super E> filter)
+func (list *UnrolledList[E]) RemoveIf(filter util.Predicate[E]) bool {
+	items := list.ToArray()
+	kept := slices.DeleteFunc(items, filter)
+	if len(kept) == list.size {
+		return false
+	}
+	list.Clear()
+	list.AddAll(kept)
+	return true
+}
+
+// Removes and returns the last element of this list, and panics if this list is empty.
+// E removeLast()
+func (list *UnrolledList[E]) RemoveLast() E {
+	if list.last == nil {
+		panic("No such element")
+	}
+	return list.removeAt(list.last, len(list.last.items)-1)
+}
+
+// Replaces each element of this list with the result of applying the operator to that element.
+// void replaceAll(UnaryOperator<E> operator)
+func (list *UnrolledList[E]) ReplaceAll(operator util.UnaryOperator[E]) {
+	for n := list.first; n != nil; n = n.next {
+		for i, v := range n.items {
+			n.items[i] = operator(v)
+		}
+	}
+}
+
+// Retains only the elements in this list that are contained in the specified slice, in a single pass.
+// boolean retainAll(Collection<?> c)
+func (list *UnrolledList[E]) RetainAll(items []E) bool {
+	contains := listutil.Membership(items, list.equals, list.hashable)
+	return list.RemoveIf(func(v E) bool {
+		return !contains(v)
+	})
+}
+
+// Returns a reverse-ordered view of this list, as collections.Reversed does.
+// Changes made through the view are written through to this list, and changes to this list are visible in the view.
+// List<E> reversed()
+func (list *UnrolledList[E]) Reversed() collection.List[E] {
+	return collections.Reversed[E](list)
+}
+
+// Replaces the element at the specified position in this list with the specified element.
+// Returns the element previously at the specified position.
+// E set(int index, E element)
+func (list *UnrolledList[E]) Set(index int, item E) E {
+	listutil.CheckIndex(index, list.size)
+	n, offset := list.position(index)
+	old := n.items[offset]
+	n.items[offset] = item
+	return old
+}
+
+// Returns the number of elements in this list.
+// int size()
+func (list *UnrolledList[E]) Size() int {
+	return list.size
+}
+
+// Sorts this list according to the order induced by the specified comparator.
+// The elements are copied into a slice, sorted stably, and written back into the nodes in place.
+// If the comparator is nil, the elements are sorted by util.DefaultComparator, which uses their natural ordering.
+// void sort(Comparator<? super E> c)
+func (list *UnrolledList[E]) Sort(comparator util.Comparator[E]) {
+	if comparator == nil {
+		comparator = util.DefaultComparator[E]()
+	}
+	items := list.ToArray()
+	slices.SortStableFunc(items, comparator)
+	for n := list.first; n != nil; n = n.next {
+		items = items[copy(n.items, items):]
+	}
+}
+
+// SortOrdered sorts the list of an ordered type, such as int64, uint or float32, in its natural ordering.
+func SortOrdered[E cmp.Ordered](list *UnrolledList[E]) {
+	list.Sort(util.NaturalOrder[E]())
+}
+
+// Returns an array containing all of the elements in this list, in order.
+// Object[] toArray()
+func (list *UnrolledList[E]) ToArray() []E {
+	items := make([]E, 0, list.size)
+	for n := list.first; n != nil; n = n.next {
+		items = append(items, n.items...)
+	}
+	return items
+}
+
+// Returns a string representation of this list, in the form "[e1, e2, e3]".
+// String toString()
+func (list *UnrolledList[E]) String() string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	i := 0
+	for v := range list.All() {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, v)
+		i++
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// position returns the node and the offset within it of the element at the index, which must be in the range [0, size].
+// The index size is located just past the last element of the last node, or at a nil node if the list is empty.
+func (list *UnrolledList[E]) position(index int) (*node[E], int) {
+	if index == list.size {
+		if list.last == nil {
+			return nil, 0
+		}
+		return list.last, len(list.last.items)
+	}
+	if index < list.size/2 {
+		n := list.first
+		for index >= len(n.items) {
+			index -= len(n.items)
+			n = n.next
+		}
+		return n, index
+	}
+	start := list.size
+	n := list.last
+	for {
+		start -= len(n.items)
+		if index >= start {
+			return n, index - start
+		}
+		n = n.prev
+	}
+}
+
+// insertAt inserts the element at the offset within the node, or into a new node if n is nil, and returns the node and
+// offset just past the inserted element. A full node is split in half first, except when appending at the end of the
+// last node, where a new node is started instead so that a list built by appending keeps its nodes full.
+func (list *UnrolledList[E]) insertAt(n *node[E], offset int, item E) (*node[E], int) {
+	if n == nil {
+		n = list.newNode(nil)
+	} else if len(n.items) == list.nodeCapacity {
+		if n == list.last && offset == len(n.items) {
+			n, offset = list.newNode(n), 0
+		} else {
+			half := len(n.items) / 2
+			m := list.newNode(n)
+			m.items = append(m.items, n.items[half:]...)
+			clear(n.items[half:])
+			n.items = n.items[:half]
+			if offset > half {
+				n, offset = m, offset-half
+			}
+		}
+	}
+	n.items = slices.Insert(n.items, offset, item)
+	list.size++
+	list.modCount++
+	return n, offset + 1
+}
+
+// removeAt removes and returns the element at the offset within the node.
+// An emptied node is unlinked, and a node left less than half full is merged with its successor when they fit in one node.
+func (list *UnrolledList[E]) removeAt(n *node[E], offset int) E {
+	item := n.items[offset]
+	n.items = slices.Delete(n.items, offset, offset+1)
+	list.size--
+	list.modCount++
+	switch {
+	case len(n.items) == 0:
+		list.unlink(n)
+	case len(n.items) < list.nodeCapacity/2 && n.next != nil && len(n.items)+len(n.next.items) <= list.nodeCapacity:
+		next := n.next
+		n.items = append(n.items, next.items...)
+		clear(next.items)
+		list.unlink(next)
+	}
+	return item
+}
+
+// newNode links a new empty node after prev, or as the first node if prev is nil, and returns it.
+func (list *UnrolledList[E]) newNode(prev *node[E]) *node[E] {
+	n := &node[E]{items: make([]E, 0, list.nodeCapacity), prev: prev}
+	if prev == nil {
+		n.next = list.first
+		list.first = n
+	} else {
+		n.next = prev.next
+		prev.next = n
+	}
+	if n.next == nil {
+		list.last = n
+	} else {
+		n.next.prev = n
+	}
+	return n
+}
+
+// unlink unlinks the node from this list.
+func (list *UnrolledList[E]) unlink(n *node[E]) {
+	if n.prev == nil {
+		list.first = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next == nil {
+		list.last = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+	*n = node[E]{}
+}
@@ -0,0 +1,239 @@
+package unrolledlist
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestAllFailsFast(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(list *UnrolledList[int])
+	}{
+		{"Add", func(list *UnrolledList[int]) { list.Add(9) }},
+		{"AddFirst", func(list *UnrolledList[int]) { list.AddFirst(9) }},
+		{"RemoveAt", func(list *UnrolledList[int]) { list.RemoveAt(0) }},
+		{"RemoveIf", func(list *UnrolledList[int]) { list.RemoveIf(func(v int) bool { return v == 3 }) }},
+		{"Clear", func(list *UnrolledList[int]) { list.Clear() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, seq := range []string{"All", "Backward", "ForEach"} {
+				list := NewFromSlice([]int{1, 2, 3, 4, 5})
+				func() {
+					defer func() {
+						if r := recover(); r != "Concurrent modification" {
+							t.Errorf("%s: recovered %v, want Concurrent modification", seq, r)
+						}
+					}()
+					switch seq {
+					case "All":
+						for range list.All() {
+							tt.modify(list)
+						}
+					case "Backward":
+						for range list.Backward() {
+							tt.modify(list)
+						}
+					case "ForEach":
+						list.ForEach(func(int) { tt.modify(list) })
+					}
+				}()
+			}
+		})
+	}
+}
+
+func TestAllAllowsSet(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3})
+	i := 0
+	for v := range list.All() {
+		list.Set(i, v*10)
+		i++
+	}
+	if got := list.ToArray(); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("ToArray() = %v, want [10 20 30]", got)
+	}
+}
+
+func TestAllAfterBreak(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3})
+	for v := range list.All() {
+		if v == 2 {
+			list.Remove(v)
+			break
+		}
+	}
+	if got := list.ToArray(); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("ToArray() = %v, want [1 3]", got)
+	}
+}
+
+// nodeSizes returns the number of elements in each node of the list, in order.
+func nodeSizes[E any](list *UnrolledList[E]) []int {
+	var sizes []int
+	for n := list.first; n != nil; n = n.next {
+		sizes = append(sizes, len(n.items))
+	}
+	return sizes
+}
+
+// checkUnrolledList fails the test unless the list holds the elements of the model in consistently linked nodes,
+// each holding between one and nodeCapacity elements.
+func checkUnrolledList(t *testing.T, list *UnrolledList[int], model []int) {
+	t.Helper()
+	var prev *node[int]
+	size := 0
+	for n := list.first; n != nil; n = n.next {
+		if n.prev != prev {
+			t.Fatalf("node %v has prev %p, want %p", n.items, n.prev, prev)
+		}
+		if len(n.items) == 0 || len(n.items) > list.nodeCapacity {
+			t.Fatalf("node holds %d elements, want 1 to %d", len(n.items), list.nodeCapacity)
+		}
+		size += len(n.items)
+		prev = n
+	}
+	if list.last != prev {
+		t.Fatal("last does not point to the last node")
+	}
+	if size != list.size {
+		t.Fatalf("nodes hold %d elements for size %d", size, list.size)
+	}
+	if got := list.ToArray(); !slices.Equal(got, model) {
+		t.Fatalf("ToArray() = %v, want %v", got, model)
+	}
+	for i := range model {
+		if got := list.Get(i); got != model[i] {
+			t.Fatalf("Get(%d) = %d, want %d", i, got, model[i])
+		}
+	}
+}
+
+func TestInsertSplitsFullNode(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3, 4}, WithNodeCapacity[int](4))
+	list.AddAt(1, 10)
+	if got := nodeSizes(list); !slices.Equal(got, []int{3, 2}) {
+		t.Errorf("node sizes = %v after inserting into the first half, want [3 2]", got)
+	}
+	checkUnrolledList(t, list, []int{1, 10, 2, 3, 4})
+
+	list = NewFromSlice([]int{1, 2, 3, 4}, WithNodeCapacity[int](4))
+	list.AddAt(3, 10)
+	if got := nodeSizes(list); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("node sizes = %v after inserting into the second half, want [2 3]", got)
+	}
+	checkUnrolledList(t, list, []int{1, 2, 3, 10, 4})
+}
+
+func TestAppendKeepsNodesFull(t *testing.T) {
+	list := New(WithNodeCapacity[int](2))
+	model := []int{1, 2, 3, 4, 5}
+	for _, v := range model {
+		list.AddAt(list.Size(), v)
+	}
+	if got := nodeSizes(list); !slices.Equal(got, []int{2, 2, 1}) {
+		t.Errorf("node sizes = %v after appending, want [2 2 1]", got)
+	}
+	checkUnrolledList(t, list, model)
+
+	// appending to the end of a full node that is not the last one splits it
+	list.AddAt(2, 6)
+	checkUnrolledList(t, list, []int{1, 2, 6, 3, 4, 5})
+}
+
+func TestRemoveMergesNodes(t *testing.T) {
+	list := NewFromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, WithNodeCapacity[int](4))
+	if got := nodeSizes(list); !slices.Equal(got, []int{4, 4, 2}) {
+		t.Fatalf("node sizes = %v, want [4 4 2]", got)
+	}
+	list.RemoveAt(1)
+	list.RemoveAt(1)
+	// the first node is under half full and fits with its successor
+	if got := nodeSizes(list); !slices.Equal(got, []int{2, 4, 2}) {
+		t.Errorf("node sizes = %v, want [2 4 2]", got)
+	}
+	list.RemoveAt(4)
+	list.RemoveAt(4)
+	// the middle node is left at half capacity, so it is not merged until it drops below
+	if got := nodeSizes(list); !slices.Equal(got, []int{2, 2, 2}) {
+		t.Errorf("node sizes = %v, want [2 2 2]", got)
+	}
+	list.RemoveAt(2)
+	if got := nodeSizes(list); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("node sizes = %v, want [2 3]", got)
+	}
+	checkUnrolledList(t, list, []int{1, 4, 6, 9, 10})
+
+	for range 3 {
+		list.RemoveAt(2)
+	}
+	if got := nodeSizes(list); !slices.Equal(got, []int{2}) {
+		t.Errorf("node sizes = %v after emptying the last node, want [2]", got)
+	}
+	checkUnrolledList(t, list, []int{1, 4})
+}
+
+func TestPositionFromBothEnds(t *testing.T) {
+	list := New(WithNodeCapacity[int](4))
+	var model []int
+	for i := range 40 {
+		// inserting at varying positions leaves nodes of different sizes
+		at := (i * 7) % (len(model) + 1)
+		list.AddAt(at, i+1)
+		model = slices.Insert(model, at, i+1)
+	}
+	checkUnrolledList(t, list, model)
+	for i := range model {
+		n, offset := list.position(i)
+		if n.items[offset] != model[i] {
+			t.Fatalf("position(%d) holds %d, want %d", i, n.items[offset], model[i])
+		}
+	}
+	if n, offset := list.position(list.Size()); n != list.last || offset != len(list.last.items) {
+		t.Error("position(size) is not just past the last element")
+	}
+}
+
+// TestModel applies random edits to UnrolledLists of several node capacities and to a plain slice,
+// and compares them after each edit.
+func TestModel(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 9))
+	for _, capacity := range []int{2, 3, 4, 32} {
+		list := New(WithNodeCapacity[int](capacity))
+		var model []int
+		next := 1
+		for range 2000 {
+			switch op := r.IntN(10); {
+			case op < 4:
+				i := r.IntN(len(model) + 1)
+				items := make([]int, r.IntN(3)+1)
+				for j := range items {
+					items[j] = next
+					next++
+				}
+				list.AddAllAt(i, items)
+				model = slices.Insert(model, i, items...)
+			case op < 7 && len(model) > 0:
+				i := r.IntN(len(model))
+				if got := list.RemoveAt(i); got != model[i] {
+					t.Fatalf("RemoveAt(%d) = %d, want %d", i, got, model[i])
+				}
+				model = slices.Delete(model, i, i+1)
+			case op == 7:
+				list.AddFirst(next)
+				model = slices.Insert(model, 0, next)
+				next++
+			case op == 8 && len(model) > 0:
+				list.RemoveLast()
+				model = model[:len(model)-1]
+			case op == 9:
+				div := r.IntN(5) + 2
+				list.RemoveIf(func(v int) bool { return v%div == 0 })
+				model = slices.DeleteFunc(model, func(v int) bool { return v%div == 0 })
+			}
+			checkUnrolledList(t, list, model)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"net/netip"
+)
+
+// AddrComparator compares two IP addresses numerically, with IPv4 addresses ordered before IPv6 addresses,
+// and addresses without a zone ordered before the same address with one. The invalid zero Addr is ordered first.
+func AddrComparator(a, b netip.Addr) int {
+	return a.Compare(b)
+}
+
+// PrefixComparator compares two IP prefixes by their addresses, and then by their lengths.
+func PrefixComparator(a, b netip.Prefix) int {
+	if c := a.Addr().Compare(b.Addr()); c != 0 {
+		return c
+	}
+	return a.Bits() - b.Bits()
+}
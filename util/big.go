@@ -0,0 +1,17 @@
+package util
+
+import (
+	"math/big"
+)
+
+// BigIntComparator compares two non-nil big integers numerically.
+// int compareTo(BigInteger val)
+func BigIntComparator(a, b *big.Int) int {
+	return a.Cmp(b)
+}
+
+// BigFloatComparator compares two non-nil big floats numerically, ignoring their precision.
+// int compareTo(BigDecimal val)
+func BigFloatComparator(a, b *big.Float) int {
+	return a.Cmp(b)
+}
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"bytes"
+)
+
+// BytesComparator compares two byte slices lexicographically, treating a nil slice as empty.
+// static int compare(byte[] a, byte[] b)
+func BytesComparator(a, b []byte) int {
+	return bytes.Compare(a, b)
+}
+
+// BytesEquals reports whether two byte slices have the same length and contents, treating a nil slice as empty.
+// static boolean equals(byte[] a, byte[] a2)
+func BytesEquals(a, b []byte) bool {
+	return bytes.Equal(a, b)
+}
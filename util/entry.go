@@ -0,0 +1,39 @@
+package util
+
+import (
+	"fmt"
+)
+
+// Entry is a key-value pair, such as an entry of a map.
+type Entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// NewEntry returns an Entry with the given key and value.
+// static <K, V> Map.Entry<K, V> entry(K k, V v)
+func NewEntry[K, V any](key K, value V) Entry[K, V] {
+	return Entry[K, V]{Key: key, Value: value}
+}
+
+// Returns a string representation of this entry, in the form "key=value".
+// String toString()
+func (e Entry[K, V]) String() string {
+	return fmt.Sprintf("%v=%v", e.Key, e.Value)
+}
+
+// ByKey returns a comparator that compares entries by their keys with the given comparator.
+// static <K, V> Comparator<Map.Entry<K, V>> comparingByKey(Comparator<? super K> cmp)
+func ByKey[K, V any](comparator Comparator[K]) Comparator[Entry[K, V]] {
+	return func(a, b Entry[K, V]) int {
+		return comparator(a.Key, b.Key)
+	}
+}
+
+// ByValue returns a comparator that compares entries by their values with the given comparator.
+// static <K, V> Comparator<Map.Entry<K, V>> comparingByValue(Comparator<? super V> cmp)
+func ByValue[K, V any](comparator Comparator[V]) Comparator[Entry[K, V]] {
+	return func(a, b Entry[K, V]) int {
+		return comparator(a.Value, b.Value)
+	}
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"math"
+)
+
+// NaNPolicy determines how a floating-point comparator orders NaN, which is unordered with respect to every value.
+type NaNPolicy int
+
+const (
+	// NaNFirst orders NaN before any other value, as cmp.Compare and NaturalOrder do.
+	NaNFirst NaNPolicy = iota
+
+	// NaNLast orders NaN after any other value, including positive infinity, as Java's Double.compare does.
+	NaNLast
+
+	// NaNPanic makes the comparator panic when it is given NaN.
+	NaNPanic
+)
+
+// Float64Comparator returns a comparator of float64 values that orders NaN according to the given policy.
+// NaN values are equal to each other, and -0.0 is equal to 0.0.
+// static int compare(double d1, double d2)
+func Float64Comparator(policy NaNPolicy) Comparator[float64] {
+	return floatComparator[float64](policy)
+}
+
+// Float32Comparator returns a comparator of float32 values that orders NaN according to the given policy.
+// NaN values are equal to each other, and -0.0 is equal to 0.0.
+// static int compare(float f1, float f2)
+func Float32Comparator(policy NaNPolicy) Comparator[float32] {
+	return floatComparator[float32](policy)
+}
+
+// floatComparator returns a comparator of floating-point values that orders NaN according to the given policy.
+func floatComparator[T ~float32 | ~float64](policy NaNPolicy) Comparator[T] {
+	nanOrder := -1
+	switch policy {
+	case NaNFirst:
+	case NaNLast:
+		nanOrder = 1
+	case NaNPanic:
+		nanOrder = 0
+	default:
+		panic("Unknown NaN policy")
+	}
+	return func(a, b T) int {
+		aNaN, bNaN := math.IsNaN(float64(a)), math.IsNaN(float64(b))
+		if aNaN || bNaN {
+			switch {
+			case nanOrder == 0:
+				panic("NaN is not comparable")
+			case aNaN && bNaN:
+				return 0
+			case aNaN:
+				return nanOrder
+			default:
+				return -nanOrder
+			}
+		}
+		if a < b {
+			return -1
+		} else if a > b {
+			return 1
+		}
+		return 0
+	}
+}
@@ -0,0 +1,21 @@
+package util
+
+import (
+	"hash/maphash"
+)
+
+// Hash is a function type that computes the hash code of an element.
+// Elements that are equal according to the Equals function of a collection must have the same hash code.
+type Hash[E any] func(e E) uint64
+
+// hashSeed is the seed of DefaultHash, chosen randomly once per process.
+var hashSeed = maphash.MakeSeed()
+
+// DefaultHash returns a hash function for comparable types that is consistent with ==.
+// Hash codes are randomized per process and must not be persisted.
+// int hashCode()
+func DefaultHash[E comparable]() Hash[E] {
+	return func(e E) uint64 {
+		return maphash.Comparable(hashSeed, e)
+	}
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIndexOutOfBounds is matched by the errors returned when an index or a range is out of bounds.
+var ErrIndexOutOfBounds = errors.New("util: index out of bounds")
+
+// indexError describes an index or a range that is out of bounds.
+type indexError struct {
+	msg string
+}
+
+func (e *indexError) Error() string {
+	return e.msg
+}
+
+func (e *indexError) Unwrap() error {
+	return ErrIndexOutOfBounds
+}
+
+// CheckIndex returns an error matching ErrIndexOutOfBounds if index is not in the range [0, size).
+// static int checkIndex(int index, int length)
+func CheckIndex(index, size int) error {
+	if index < 0 || index >= size {
+		return &indexError{fmt.Sprintf("util: index %d out of bounds for length %d", index, size)}
+	}
+	return nil
+}
+
+// CheckFromToIndex returns an error matching ErrIndexOutOfBounds if the range [from, to) is not within [0, size).
+// static int checkFromToIndex(int fromIndex, int toIndex, int length)
+func CheckFromToIndex(from, to, size int) error {
+	if from < 0 || from > to || to > size {
+		return &indexError{fmt.Sprintf("util: range [%d, %d) out of bounds for length %d", from, to, size)}
+	}
+	return nil
+}
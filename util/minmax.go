@@ -0,0 +1,87 @@
+package util
+
+// Min returns the lesser of two elements according to the comparator, or a if they are equal.
+func Min[T any](comparator Comparator[T], a, b T) T {
+	if comparator(a, b) <= 0 {
+		return a
+	}
+	return b
+}
+
+// Max returns the greater of two elements according to the comparator, or a if they are equal.
+func Max[T any](comparator Comparator[T], a, b T) T {
+	if comparator(a, b) >= 0 {
+		return a
+	}
+	return b
+}
+
+// MinBy returns a BinaryOperator that returns the lesser of two elements according to the comparator.
+// static <T> BinaryOperator<T> minBy(Comparator<? super T> comparator)
+func MinBy[T any](comparator Comparator[T]) BinaryOperator[T] {
+	return func(a, b T) T {
+		return Min(comparator, a, b)
+	}
+}
+
+// MaxBy returns a BinaryOperator that returns the greater of two elements according to the comparator.
+// static <T> BinaryOperator<T> maxBy(Comparator<? super T> comparator)
+func MaxBy[T any](comparator Comparator[T]) BinaryOperator[T] {
+	return func(a, b T) T {
+		return Max(comparator, a, b)
+	}
+}
+
+// MinOf returns the least of the given elements according to the comparator, or the first of the least elements if there are several.
+// static <T> T min(Collection<? extends T> coll, Comparator<? super T> comp)
+func MinOf[T any](comparator Comparator[T], first T, rest ...T) T {
+	result := first
+	for _, item := range rest {
+		result = Min(comparator, result, item)
+	}
+	return result
+}
+
+// MaxOf returns the greatest of the given elements according to the comparator, or the first of the greatest elements if there are several.
+// static <T> T max(Collection<? extends T> coll, Comparator<? super T> comp)
+func MaxOf[T any](comparator Comparator[T], first T, rest ...T) T {
+	result := first
+	for _, item := range rest {
+		result = Max(comparator, result, item)
+	}
+	return result
+}
+
+// MinSlice returns the least element of the slice according to the comparator, and panics if the slice is empty.
+// static <T> T min(Collection<? extends T> coll, Comparator<? super T> comp)
+func MinSlice[T any](comparator Comparator[T], items []T) T {
+	if len(items) == 0 {
+		panic("No such element")
+	}
+	return MinOf(comparator, items[0], items[1:]...)
+}
+
+// MaxSlice returns the greatest element of the slice according to the comparator, and panics if the slice is empty.
+// static <T> T max(Collection<? extends T> coll, Comparator<? super T> comp)
+func MaxSlice[T any](comparator Comparator[T], items []T) T {
+	if len(items) == 0 {
+		panic("No such element")
+	}
+	return MaxOf(comparator, items[0], items[1:]...)
+}
+
+// Clamp returns v limited to the range [lo, hi] according to the comparator,
+// and panics if lo is greater than hi.
+// static int clamp(long value, int min, int max)
+func Clamp[T any](comparator Comparator[T], v, lo, hi T) T {
+	if comparator(lo, hi) > 0 {
+		panic("Lower bound is greater than upper bound")
+	}
+	if comparator(v, lo) < 0 {
+		return lo
+	}
+	if comparator(v, hi) > 0 {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,26 @@
+package util
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// CaseInsensitiveOrder compares two strings rune by rune, ignoring case differences.
+// Runes are compared after mapping them to upper case and then to lower case, as Java does,
+// so the ordering is consistent with strings.EqualFold for simple case foldings. It does not take locale into account.
+// static final Comparator<String> CASE_INSENSITIVE_ORDER
+func CaseInsensitiveOrder(a, b string) int {
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			ra = unicode.ToLower(unicode.ToUpper(ra))
+			rb = unicode.ToLower(unicode.ToUpper(rb))
+			if ra != rb {
+				return int(ra) - int(rb)
+			}
+		}
+		a, b = a[na:], b[nb:]
+	}
+	return utf8.RuneCountInString(a) - utf8.RuneCountInString(b)
+}
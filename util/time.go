@@ -0,0 +1,30 @@
+package util
+
+import (
+	"cmp"
+	"time"
+)
+
+// TimeComparator compares two instants in chronological order.
+// int compareTo(Instant otherInstant)
+func TimeComparator(a, b time.Time) int {
+	return a.Compare(b)
+}
+
+// TimeEquals reports whether two times represent the same instant, regardless of their locations.
+// boolean equals(Object otherInstant)
+func TimeEquals(a, b time.Time) bool {
+	return a.Equal(b)
+}
+
+// DurationComparator compares two durations from shortest to longest.
+// int compareTo(Duration otherDuration)
+func DurationComparator(a, b time.Duration) int {
+	return cmp.Compare(a, b)
+}
+
+// DurationEquals reports whether two durations are equal.
+// boolean equals(Object otherDuration)
+func DurationEquals(a, b time.Duration) bool {
+	return a == b
+}
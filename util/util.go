@@ -1,37 +1,250 @@
 package util
 
 import (
-	"strings"
+	"cmp"
+	"fmt"
+	"reflect"
 )
 
 // Comparator is a function type that compares two elements.
 // The return value is negative if a < b, 0 if a == b, and positive if a > b.
 type Comparator[T any] func(o1, o2 T) int
 
+// Less returns a function that reports whether a is ordered before b, for use with sort.Slice,
+// sort.SliceStable and other APIs that expect a less function.
+func (c Comparator[T]) Less() func(a, b T) bool {
+	return func(a, b T) bool {
+		return c(a, b) < 0
+	}
+}
+
+// ToCmpFunc returns the comparator as a plain comparison function, for use with slices.SortFunc,
+// slices.BinarySearchFunc and other APIs that follow the cmp.Compare convention.
+func (c Comparator[T]) ToCmpFunc() func(a, b T) int {
+	return c
+}
+
+// Comparable is implemented by types that define their own natural ordering.
+// CompareTo returns a negative integer, zero, or a positive integer as this element is less than, equal to, or greater than the other element.
+// DefaultComparator, and therefore every collection created without a comparator, orders such elements by calling it.
+// int compareTo(T o)
+type Comparable[T any] interface {
+	CompareTo(other T) int
+}
+
 // Equals is a function type that compares the equality of two elements.
 type Equals[E any] func(a, b E) bool
 
-// defaultComparator is the default comparator function, used when the element is comparable.
-// It is useful when the element is an int, float64, string.
+// Predicate is a function type that tests an element against a condition.
+type Predicate[T any] func(t T) bool
+
+// Consumer is a function type that performs an action on an element.
+type Consumer[T any] func(t T)
+
+// BiConsumer is a function type that performs an action on a pair of values, such as a key and its value.
+type BiConsumer[T, U any] func(t T, u U)
+
+// UnaryOperator is a function type that produces an element from an element of the same type.
+type UnaryOperator[T any] func(t T) T
+
+// BinaryOperator is a function type that combines two elements into an element of the same type.
+type BinaryOperator[T any] func(t, u T) T
+
+// DefaultComparator returns the comparator used when no comparator is given.
+// It compares int, float64 and string elements in their natural order, as cmp.Compare does, so NaN is ordered first
+// as with the NaNFirst policy of Float64Comparator, and elements
+// that implement Comparable by calling CompareTo. For an interface type, the dynamic types are checked on each comparison.
+// For any other type, the returned comparator panics when called, since no ordering can be derived for it.
 func DefaultComparator[T any]() Comparator[T] {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return any(NaturalOrder[int]()).(Comparator[T])
+	case float64:
+		return any(NaturalOrder[float64]()).(Comparator[T])
+	case string:
+		return any(NaturalOrder[string]()).(Comparator[T])
+	case Comparable[T]:
+		return func(a, b T) int {
+			return any(a).(Comparable[T]).CompareTo(b)
+		}
+	case nil:
+		// T is an interface type
+		return func(a, b T) int {
+			switch aTyped := any(a).(type) {
+			case int:
+				return cmp.Compare(aTyped, any(b).(int))
+			case float64:
+				return cmp.Compare(aTyped, any(b).(float64))
+			case string:
+				return cmp.Compare(aTyped, any(b).(string))
+			case Comparable[T]:
+				return aTyped.CompareTo(b)
+			default:
+				panic(fmt.Sprintf("Unsupported element type %T", a))
+			}
+		}
+	default:
+		return func(a, b T) int {
+			panic(fmt.Sprintf("Unsupported element type %T", a))
+		}
+	}
+}
+
+// DeepEquals returns an equality function backed by reflect.DeepEqual.
+// It works for any type, including slices, maps and structs containing them, at the cost of reflection on every call.
+// static boolean deepEquals(Object a, Object b)
+func DeepEquals[E any]() Equals[E] {
+	return func(a, b E) bool {
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// EqualsOf returns an equality function that compares comparable elements with ==, without boxing them in interfaces.
+// static boolean equals(Object a, Object b)
+func EqualsOf[E comparable]() Equals[E] {
+	return func(a, b E) bool {
+		return a == b
+	}
+}
+
+// DefaultEquals returns the equality function used by collections when none is given.
+// The predeclared ordered types are compared with EqualsOf, without boxing them in interfaces, and other comparable types with ==.
+// Interface types are compared with == when their dynamic values are comparable, and with reflect.DeepEqual otherwise,
+// as are types that cannot be compared with ==, such as slices and maps.
+func DefaultEquals[E any]() Equals[E] {
+	var equals any
+	var zero E
+	switch any(zero).(type) {
+	case int:
+		equals = EqualsOf[int]()
+	case int8:
+		equals = EqualsOf[int8]()
+	case int16:
+		equals = EqualsOf[int16]()
+	case int32:
+		equals = EqualsOf[int32]()
+	case int64:
+		equals = EqualsOf[int64]()
+	case uint:
+		equals = EqualsOf[uint]()
+	case uint8:
+		equals = EqualsOf[uint8]()
+	case uint16:
+		equals = EqualsOf[uint16]()
+	case uint32:
+		equals = EqualsOf[uint32]()
+	case uint64:
+		equals = EqualsOf[uint64]()
+	case uintptr:
+		equals = EqualsOf[uintptr]()
+	case float64:
+		equals = EqualsOf[float64]()
+	case float32:
+		equals = EqualsOf[float32]()
+	case string:
+		equals = EqualsOf[string]()
+	}
+	if equals != nil {
+		return equals.(Equals[E])
+	}
+
+	t := reflect.TypeFor[E]()
+	switch {
+	case t.Kind() == reflect.Interface:
+		return func(a, b E) bool {
+			if ta := reflect.TypeOf(any(a)); ta != nil && !ta.Comparable() {
+				return reflect.DeepEqual(a, b)
+			}
+			return any(a) == any(b)
+		}
+	case t.Comparable():
+		return func(a, b E) bool {
+			return any(a) == any(b)
+		}
+	default:
+		return DeepEquals[E]()
+	}
+}
+
+// NaturalOrder returns a comparator that compares ordered elements in their natural order.
+// Unlike DefaultComparator, it supports every integer, unsigned integer, floating-point and string type,
+// and the element type is checked at compile time. NaN is ordered before any other floating-point value.
+// static <T extends Comparable<? super T>> Comparator<T> naturalOrder()
+func NaturalOrder[T cmp.Ordered]() Comparator[T] {
+	return cmp.Compare[T]
+}
+
+// ReverseOrder returns a comparator that imposes the reverse ordering of the given comparator.
+// static <T> Comparator<T> reverseOrder(Comparator<T> cmp)
+func ReverseOrder[T any](comparator Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		return comparator(b, a)
+	}
+}
+
+// Comparing returns a comparator that compares elements by the key extracted with the given function,
+// using the given comparator for the keys.
+// static <T,U> Comparator<T> comparing(Function<? super T,? extends U> keyExtractor, Comparator<? super U> keyComparator)
+func Comparing[T, K any](key func(T) K, comparator Comparator[K]) Comparator[T] {
+	return func(a, b T) int {
+		return comparator(key(a), key(b))
+	}
+}
+
+// ComparingOrdered returns a comparator that compares elements by the key extracted with the given function,
+// using the natural ordering of the keys.
+// static <T,U extends Comparable<? super U>> Comparator<T> comparing(Function<? super T,? extends U> keyExtractor)
+func ComparingOrdered[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
 	return func(a, b T) int {
-		switch aTyped := any(a).(type) {
-		case int:
-			bTyped := any(b).(int)
-			return aTyped - bTyped
-		case float64:
-			bTyped := any(b).(float64)
-			if aTyped < bTyped {
-				return -1
-			} else if aTyped > bTyped {
-				return 1
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// SliceComparator returns a comparator that compares slices lexicographically, using the given comparator for the elements.
+// The first pair of unequal elements determines the order, and a slice that is a prefix of the other is ordered first.
+// static <T> int compare(T[] a, T[] b, Comparator<? super T> cmp)
+func SliceComparator[E any](elem Comparator[E]) Comparator[[]E] {
+	return func(a, b []E) int {
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if c := elem(a[i], b[i]); c != 0 {
+				return c
 			}
+		}
+		return cmp.Compare(len(a), len(b))
+	}
+}
+
+// NilFirst returns a comparator of pointers that orders nil before non-nil pointers,
+// and compares the values pointed to by two non-nil pointers with the given comparator.
+// If the comparator is nil, all non-nil pointers are considered equal.
+// static <T> Comparator<T> nullsFirst(Comparator<? super T> comparator)
+func NilFirst[T any](comparator Comparator[T]) Comparator[*T] {
+	return nilComparator(comparator, -1)
+}
+
+// NilLast returns a comparator of pointers that orders nil after non-nil pointers,
+// and compares the values pointed to by two non-nil pointers with the given comparator.
+// If the comparator is nil, all non-nil pointers are considered equal.
+// static <T> Comparator<T> nullsLast(Comparator<? super T> comparator)
+func NilLast[T any](comparator Comparator[T]) Comparator[*T] {
+	return nilComparator(comparator, 1)
+}
+
+// nilComparator returns a comparator of pointers where nil compares as nilOrder against non-nil pointers.
+func nilComparator[T any](comparator Comparator[T], nilOrder int) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
 			return 0
-		case string:
-			bTyped := any(b).(string)
-			return strings.Compare(aTyped, bTyped)
-		default:
+		case a == nil:
+			return nilOrder
+		case b == nil:
+			return -nilOrder
+		case comparator == nil:
 			return 0
+		default:
+			return comparator(*a, *b)
 		}
 	}
 }
@@ -0,0 +1,153 @@
+package util
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// version is a Comparable type ordered by its number alone.
+type version struct {
+	n    int
+	name string
+}
+
+func (v version) CompareTo(other version) int {
+	return v.n - other.n
+}
+
+func TestDefaultComparator(t *testing.T) {
+	if c := DefaultComparator[int]()(1, 2); c >= 0 {
+		t.Errorf("compare(1, 2) = %d, want negative", c)
+	}
+	if c := DefaultComparator[string]()("b", "a"); c <= 0 {
+		t.Errorf(`compare("b", "a") = %d, want positive`, c)
+	}
+	if c := DefaultComparator[version]()(version{2, "a"}, version{2, "b"}); c != 0 {
+		t.Errorf("compare by CompareTo = %d, want 0", c)
+	}
+	if c := DefaultComparator[any]()(1, 3); c >= 0 {
+		t.Errorf("compare(any(1), any(3)) = %d, want negative", c)
+	}
+}
+
+func TestDefaultComparatorUnsupportedPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"struct":    func() { DefaultComparator[struct{ x int }]()(struct{ x int }{}, struct{ x int }{}) },
+		"interface": func() { DefaultComparator[any]()([]int{}, []int{}) },
+		// a Comparable[version] is not a Comparable[any]
+		"mismatched CompareTo": func() { DefaultComparator[any]()(version{1, ""}, version{3, ""}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: comparing an unsupported type did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestFloat64ComparatorNaNPolicy(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	first, last := Float64Comparator(NaNFirst), Float64Comparator(NaNLast)
+	if first(nan, math.Inf(-1)) >= 0 || first(nan, nan) != 0 {
+		t.Error("NaNFirst does not order NaN before -Inf and equal to NaN")
+	}
+	if last(nan, inf) <= 0 || last(inf, nan) >= 0 || last(nan, nan) != 0 {
+		t.Error("NaNLast does not order NaN after +Inf and equal to NaN")
+	}
+	if first(math.Copysign(0, -1), 0) != 0 {
+		t.Error("-0.0 and 0.0 compare unequal")
+	}
+	if c := Float32Comparator(NaNLast)(float32(nan), 1); c <= 0 {
+		t.Errorf("Float32Comparator(NaNLast)(NaN, 1) = %d, want positive", c)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NaNPanic did not panic on NaN")
+		}
+	}()
+	Float64Comparator(NaNPanic)(1, nan)
+}
+
+func TestVersionComparator(t *testing.T) {
+	// in ascending order, as in the semantic versioning specification
+	ordered := []string{
+		"0.9", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.2", "1.9.0", "v1.10.0", "1.10.1",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			c := VersionComparator(ordered[i], ordered[j])
+			if (c < 0) != (i < j) || (c == 0) != (i == j) {
+				t.Errorf("VersionComparator(%q, %q) = %d", ordered[i], ordered[j], c)
+			}
+		}
+	}
+
+	for _, pair := range [][2]string{{"1.0", "1.0.0"}, {"v2.1.0", "2.1.0+build.5"}, {"1.01", "1.1"}} {
+		if c := VersionComparator(pair[0], pair[1]); c != 0 {
+			t.Errorf("VersionComparator(%q, %q) = %d, want 0", pair[0], pair[1], c)
+		}
+	}
+}
+
+type Location struct {
+	City string
+}
+
+type person struct {
+	Location
+	Name  string
+	Age   int
+	Score float64
+	age   int
+}
+
+func TestComparingFields(t *testing.T) {
+	people := []person{
+		{Location{"Seoul"}, "b", 30, 1, 0},
+		{Location{"Busan"}, "a", 30, 2, 0},
+		{Location{"Seoul"}, "c", 20, 2, 0},
+	}
+	byAgeDescThenCity := ComparingFields[person]("-Age", "Location.City")
+	sorted := slices.Clone(people)
+	slices.SortFunc(sorted, byAgeDescThenCity)
+	var names string
+	for _, p := range sorted {
+		names += p.Name
+	}
+	if names != "abc" {
+		t.Errorf("sorted by -Age, City = %s, want abc", names)
+	}
+
+	byScore := ComparingFields[*person]("Score", "City")
+	if c := byScore(&people[1], &people[2]); c >= 0 {
+		t.Errorf("compare by Score, City = %d, want negative", c)
+	}
+	if c := byScore(nil, &people[0]); c >= 0 {
+		t.Errorf("compare(nil, p) = %d, want nil ordered first", c)
+	}
+}
+
+func TestComparingFieldsPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"unknown field":    func() { ComparingFields[person]("Height") },
+		"unexported field": func() { ComparingFields[person]("age") },
+		"unknown path":     func() { ComparingFields[person]("Location.Zip") },
+		"struct field":     func() { ComparingFields[person]("Location") },
+		"not a struct":     func() { ComparingFields[int]("Age") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: ComparingFields did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,86 @@
+package util
+
+import (
+	"strings"
+)
+
+// VersionComparator compares two version strings such as "1.9.0" and "v1.10.0-rc.1" in semantic version order.
+// An optional leading "v" and any build metadata after "+" are ignored, and the dot-separated components are compared
+// numerically when both are numbers, so "1.10.0" is ordered after "1.9.0". Missing trailing components count as zero.
+// A version with a pre-release suffix after "-" is ordered before the same version without one, and pre-release
+// identifiers are compared as semantic versioning specifies.
+func VersionComparator(a, b string) int {
+	a, aPre := splitVersion(a)
+	b, bPre := splitVersion(b)
+
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		aPart, bPart := "0", "0"
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		if c := compareVersionPart(aPart, bPart); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	aIDs, bIDs := strings.Split(aPre, "."), strings.Split(bPre, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		if c := compareVersionPart(aIDs[i], bIDs[i]); c != 0 {
+			return c
+		}
+	}
+	return len(aIDs) - len(bIDs)
+}
+
+// splitVersion strips the prefix and build metadata from a version, and splits it into its core and pre-release parts.
+func splitVersion(v string) (core, pre string) {
+	v = strings.TrimPrefix(v, "v")
+	v, _, _ = strings.Cut(v, "+")
+	core, pre, _ = strings.Cut(v, "-")
+	return core, pre
+}
+
+// compareVersionPart compares two version components, numerically if both are numbers.
+// A numeric component is ordered before a non-numeric one.
+func compareVersionPart(a, b string) int {
+	aNum, bNum := isDigits(a), isDigits(b)
+	switch {
+	case aNum && bNum:
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}